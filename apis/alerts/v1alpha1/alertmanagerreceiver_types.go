@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// An AlertmanagerReceiverSpec defines the integrations of an
+// AlertmanagerReceiver.
+type AlertmanagerReceiverSpec struct {
+	// ProviderConfigReference selects the tenant the receiver is added to. It
+	// is merged into the AlertManagerConfiguration of any ProviderConfig of
+	// the same tenant.
+	// +kubebuilder:default={"name": "default"}
+	ProviderConfigReference xpv1.Reference `json:"providerConfigRef"`
+
+	// The integrations of the receiver. Routes refer to the receiver by the
+	// name of the AlertmanagerReceiver. Only the AlertmanagerRoutes of the
+	// same claim namespace can refer to it, and it is added to the
+	// configuration as <claim namespace>/<name>.
+	ReceiverConfigs `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AlertmanagerReceiver is a receiver a team adds to the Alertmanager
+// configuration of a tenant shared with other teams.
+// +kubebuilder:printcolumn:name="PROVIDER-CONFIG",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,cortex}
+type AlertmanagerReceiver struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AlertmanagerReceiverSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// AlertmanagerReceiverList contains a list of AlertmanagerReceiver
+type AlertmanagerReceiverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertmanagerReceiver `json:"items"`
+}
+
+// AlertmanagerReceiver type metadata.
+var (
+	AlertmanagerReceiverKind             = reflect.TypeOf(AlertmanagerReceiver{}).Name()
+	AlertmanagerReceiverGroupKind        = schema.GroupKind{Group: Group, Kind: AlertmanagerReceiverKind}.String()
+	AlertmanagerReceiverKindAPIVersion   = AlertmanagerReceiverKind + "." + SchemeGroupVersion.String()
+	AlertmanagerReceiverGroupVersionKind = SchemeGroupVersion.WithKind(AlertmanagerReceiverKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&AlertmanagerReceiver{}, &AlertmanagerReceiverList{})
+}
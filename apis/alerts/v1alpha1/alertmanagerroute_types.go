@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// LabelKeyClaimNamespace is the label Crossplane sets to the namespace of the
+// claim on the resources composed for it.
+const LabelKeyClaimNamespace = "crossplane.io/claim-namespace"
+
+// An AlertmanagerRouteSpec defines the sub-route of an AlertmanagerRoute.
+type AlertmanagerRouteSpec struct {
+	// ProviderConfigReference selects the tenant the route is added to. It is
+	// merged into the AlertManagerConfiguration of any ProviderConfig of the
+	// same tenant.
+	// +kubebuilder:default={"name": "default"}
+	ProviderConfigReference xpv1.Reference `json:"providerConfigRef"`
+
+	// Route is added as a child of the root route of the tenant. It only
+	// matches the alerts with the enforced matcher of the
+	// AlertManagerConfiguration, and the routes after it are always matched
+	// too. It can only use the receivers of the AlertManagerConfiguration and
+	// the AlertmanagerReceivers of the same claim namespace.
+	Route Route `json:"route"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AlertmanagerRoute is a sub-route a team adds to the Alertmanager
+// configuration of a tenant shared with other teams.
+// +kubebuilder:printcolumn:name="PROVIDER-CONFIG",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="RECEIVER",type="string",JSONPath=".spec.route.receiver"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,cortex}
+type AlertmanagerRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AlertmanagerRouteSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// AlertmanagerRouteList contains a list of AlertmanagerRoute
+type AlertmanagerRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertmanagerRoute `json:"items"`
+}
+
+// AlertmanagerRoute type metadata.
+var (
+	AlertmanagerRouteKind             = reflect.TypeOf(AlertmanagerRoute{}).Name()
+	AlertmanagerRouteGroupKind        = schema.GroupKind{Group: Group, Kind: AlertmanagerRouteKind}.String()
+	AlertmanagerRouteKindAPIVersion   = AlertmanagerRouteKind + "." + SchemeGroupVersion.String()
+	AlertmanagerRouteGroupVersionKind = SchemeGroupVersion.WithKind(AlertmanagerRouteKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&AlertmanagerRoute{}, &AlertmanagerRouteList{})
+}
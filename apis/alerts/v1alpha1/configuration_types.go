@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -34,15 +35,495 @@ type AlertManagerConfigurationParameters struct {
 	// Contains the alert manager configuration. This uses the same structure as
 	// an alert manager config file in standalone Prometheus.
 	// https://prometheus.io/docs/alerting/latest/configuration/
-	AlertmanagerConfig string `json:"alertmanager_config"`
+	// Exactly one of alertmanager_config and config must be set.
+	// +optional
+	AlertmanagerConfig string `json:"alertmanager_config,omitempty"`
+
+	// Config is the alert manager configuration in a structured form. It is
+	// serialised into an alert manager config file by the provider.
+	// Exactly one of alertmanager_config and config must be set.
+	// +optional
+	Config *AlertmanagerConfig `json:"config,omitempty"`
+
+	// EnforcedMatcherLabel is the label of the matcher every AlertmanagerRoute
+	// of the ProviderConfig is constrained by. Its value is the namespace of
+	// the claim of the AlertmanagerRoute, or its name if it has none.
+	// +kubebuilder:default=namespace
+	// +optional
+	EnforcedMatcherLabel string `json:"enforcedMatcherLabel,omitempty"`
+
+	// OnDelete selects what happens to the configuration of the tenant when
+	// the resource is deleted.
+	// +optional
+	OnDelete *OnDelete `json:"onDelete,omitempty"`
+
+	// SecretRefs are Secret keys whose values replace the placeholders
+	// ${name} in the configuration and the template files when they are
+	// pushed, e.g. api_url: ${slack_url}. The values are never written to the
+	// status of the resource.
+	// +optional
+	SecretRefs []ConfigSecretRef `json:"secretRefs,omitempty"`
+}
+
+// Actions of OnDelete.
+const (
+	// OnDeleteDelete deletes the configuration. Cortex then uses its fallback
+	// configuration for the tenant.
+	OnDeleteDelete = "Delete"
+	// OnDeleteRestore replaces the configuration with a baseline.
+	OnDeleteRestore = "Restore"
+)
+
+// OnDelete selects what happens to the configuration of the tenant when an
+// AlertManagerConfiguration is deleted.
+type OnDelete struct {
+	// Action is either Delete, which deletes the configuration and leaves the
+	// tenant with the fallback configuration of Cortex, or Restore, which
+	// replaces it with the baseline.
+	// +kubebuilder:validation:Enum=Delete;Restore
+	// +kubebuilder:default=Delete
+	// +optional
+	Action string `json:"action,omitempty"`
+
+	// BaselineRef selects the ConfigMap key holding the alert manager config
+	// file that is restored, e.g. one with a catch-all receiver. It must not
+	// use template files. Required by Restore.
+	// +optional
+	BaselineRef *ConfigMapKeySelector `json:"baselineRef,omitempty"`
+}
+
+// A ConfigMapKeySelector is a reference to a key of a ConfigMap in an
+// arbitrary namespace.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// The key to select.
+	Key string `json:"key"`
+}
+
+// A ConfigSecretRef names the value of a Secret key that replaces a
+// placeholder.
+type ConfigSecretRef struct {
+	// Name of the placeholder, which is written as ${name}.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Name string `json:"name"`
+
+	// Selects a key of a Secret.
+	SecretKeyRef xpv1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// An AlertmanagerConfig is the structured form of an alert manager config
+// file. https://prometheus.io/docs/alerting/latest/configuration/
+type AlertmanagerConfig struct {
+	// Global parameters which are the defaults of the receivers.
+	// +optional
+	Global *GlobalConfig `json:"global,omitempty"`
+
+	// Route is the root of the routing tree.
+	Route Route `json:"route"`
+
+	// Receivers of notifications.
+	// +kubebuilder:validation:MinItems=1
+	Receivers []Receiver `json:"receivers"`
+
+	// InhibitRules mute alerts while other alerts are firing.
+	// +optional
+	InhibitRules []InhibitRule `json:"inhibitRules,omitempty"`
+
+	// TimeIntervals name periods of time routes can be muted or active in.
+	// +optional
+	TimeIntervals []TimeInterval `json:"timeIntervals,omitempty"`
+
+	// Templates lists the names of the template_files notification templates
+	// are read from.
+	// +optional
+	Templates []string `json:"templates,omitempty"`
+}
+
+// GlobalConfig are the global parameters of an alert manager configuration.
+type GlobalConfig struct {
+	// ResolveTimeout is the time after which an alert without an end time is
+	// declared resolved.
+	// +optional
+	ResolveTimeout *string `json:"resolveTimeout,omitempty"`
+
+	// SMTPFrom is the default sender of email notifications.
+	// +optional
+	SMTPFrom string `json:"smtpFrom,omitempty"`
+
+	// SMTPSmarthost is the default host:port email notifications are sent
+	// through.
+	// +optional
+	SMTPSmarthost string `json:"smtpSmarthost,omitempty"`
+
+	// SMTPHello is the default hostname sent to the SMTP server.
+	// +optional
+	SMTPHello string `json:"smtpHello,omitempty"`
+
+	// SMTPAuthUsername is the default username of SMTP authentication.
+	// +optional
+	SMTPAuthUsername string `json:"smtpAuthUsername,omitempty"`
+
+	// SMTPAuthPasswordSecretRef selects the Secret key holding the default
+	// password of SMTP authentication.
+	// +optional
+	SMTPAuthPasswordSecretRef *xpv1.SecretKeySelector `json:"smtpAuthPasswordSecretRef,omitempty"`
+
+	// SMTPAuthIdentity is the default identity of SMTP authentication.
+	// +optional
+	SMTPAuthIdentity string `json:"smtpAuthIdentity,omitempty"`
+
+	// SMTPRequireTLS requires TLS for email notifications by default.
+	// +optional
+	SMTPRequireTLS *bool `json:"smtpRequireTls,omitempty"`
+
+	// SlackAPIURLSecretRef selects the Secret key holding the default webhook
+	// URL of Slack notifications.
+	// +optional
+	SlackAPIURLSecretRef *xpv1.SecretKeySelector `json:"slackApiUrlSecretRef,omitempty"`
+
+	// PagerdutyURL is the default URL of the PagerDuty API.
+	// +optional
+	PagerdutyURL string `json:"pagerdutyUrl,omitempty"`
+
+	// OpsgenieAPIURL is the default URL of the Opsgenie API.
+	// +optional
+	OpsgenieAPIURL string `json:"opsgenieApiUrl,omitempty"`
+
+	// OpsgenieAPIKeySecretRef selects the Secret key holding the default key
+	// of the Opsgenie API.
+	// +optional
+	OpsgenieAPIKeySecretRef *xpv1.SecretKeySelector `json:"opsgenieApiKeySecretRef,omitempty"`
+
+	// VictoropsAPIURL is the default URL of the VictorOps API.
+	// +optional
+	VictoropsAPIURL string `json:"victoropsApiUrl,omitempty"`
+
+	// VictoropsAPIKeySecretRef selects the Secret key holding the default
+	// key of the VictorOps API.
+	// +optional
+	VictoropsAPIKeySecretRef *xpv1.SecretKeySelector `json:"victoropsApiKeySecretRef,omitempty"`
+
+	// TelegramAPIURL is the default URL of the Telegram API.
+	// +optional
+	TelegramAPIURL string `json:"telegramApiUrl,omitempty"`
+}
+
+// A Route is a node of the routing tree. Alerts are routed to the first child
+// route they match, or to the receiver of the route if they match none. A CRD
+// schema cannot nest a type in itself, so routes nest at most three levels
+// below a Route. Deeper trees need an alertmanager_config.
+type Route struct {
+	RouteSettings `json:",inline"`
+
+	// Routes are the child routes.
+	// +optional
+	Routes []ChildRoute `json:"routes,omitempty"`
+}
+
+// A ChildRoute is a route one level below a Route.
+type ChildRoute struct {
+	RouteSettings `json:",inline"`
+
+	// Routes are the child routes.
+	// +optional
+	Routes []GrandchildRoute `json:"routes,omitempty"`
+}
+
+// A GrandchildRoute is a route two levels below a Route.
+type GrandchildRoute struct {
+	RouteSettings `json:",inline"`
+
+	// Routes are the child routes, which cannot have child routes
+	// themselves.
+	// +optional
+	Routes []RouteSettings `json:"routes,omitempty"`
+}
+
+// RouteSettings are the fields every route of the routing tree has.
+type RouteSettings struct {
+	// Receiver the alerts of the route are sent to. Inherited from the parent
+	// route if unset. Required for the root route.
+	// +optional
+	Receiver string `json:"receiver,omitempty"`
+
+	// GroupBy lists the labels alerts are grouped by. '...' groups by all
+	// labels.
+	// +optional
+	GroupBy []string `json:"groupBy,omitempty"`
+
+	// Continue matching the sibling routes after this route matched.
+	// +optional
+	Continue bool `json:"continue,omitempty"`
+
+	// Matchers alerts have to match, e.g. severity="critical".
+	// +optional
+	Matchers []string `json:"matchers,omitempty"`
+
+	// GroupWait is how long to wait before sending the first notification of a
+	// group.
+	// +optional
+	GroupWait *string `json:"groupWait,omitempty"`
+
+	// GroupInterval is how long to wait before notifying about new alerts of a
+	// group.
+	// +optional
+	GroupInterval *string `json:"groupInterval,omitempty"`
+
+	// RepeatInterval is how long to wait before repeating a notification.
+	// +optional
+	RepeatInterval *string `json:"repeatInterval,omitempty"`
+
+	// MuteTimeIntervals lists the time intervals the route is muted in.
+	// +optional
+	MuteTimeIntervals []string `json:"muteTimeIntervals,omitempty"`
+
+	// ActiveTimeIntervals lists the time intervals the route is active in.
+	// +optional
+	ActiveTimeIntervals []string `json:"activeTimeIntervals,omitempty"`
+}
+
+// A Receiver is a named set of notification integrations.
+type Receiver struct {
+	// Name of the receiver routes refer to.
+	Name string `json:"name"`
+
+	ReceiverConfigs `json:",inline"`
+}
+
+// ReceiverConfigs are the notification integrations of a receiver. They use
+// the fields of the alert manager config file, e.g. send_resolved.
+type ReceiverConfigs struct {
+	// +optional
+	EmailConfigs []EmailConfig `json:"emailConfigs,omitempty"`
+
+	// +optional
+	PagerdutyConfigs []PagerdutyConfig `json:"pagerdutyConfigs,omitempty"`
+
+	// +optional
+	SlackConfigs []SlackConfig `json:"slackConfigs,omitempty"`
+
+	// +optional
+	WebhookConfigs []WebhookConfig `json:"webhookConfigs,omitempty"`
+
+	// +optional
+	OpsgenieConfigs []OpsgenieConfig `json:"opsgenieConfigs,omitempty"`
+
+	// +optional
+	WechatConfigs []WechatConfig `json:"wechatConfigs,omitempty"`
+
+	// +optional
+	PushoverConfigs []PushoverConfig `json:"pushoverConfigs,omitempty"`
+
+	// +optional
+	VictoropsConfigs []VictoropsConfig `json:"victoropsConfigs,omitempty"`
+
+	// +optional
+	SNSConfigs []SNSConfig `json:"snsConfigs,omitempty"`
+
+	// +optional
+	TelegramConfigs []TelegramConfig `json:"telegramConfigs,omitempty"`
+}
+
+// An InhibitRule mutes the alerts matching the target matchers while an
+// alert matching the source matchers is firing.
+type InhibitRule struct {
+	// SourceMatchers the inhibiting alerts have to match.
+	// +optional
+	SourceMatchers []string `json:"sourceMatchers,omitempty"`
+
+	// TargetMatchers the muted alerts have to match.
+	// +optional
+	TargetMatchers []string `json:"targetMatchers,omitempty"`
+
+	// Equal lists the labels whose values the source and target alert must
+	// share.
+	// +optional
+	Equal []string `json:"equal,omitempty"`
+}
+
+// A TimeInterval is a named period of time.
+type TimeInterval struct {
+	// Name of the time interval routes refer to.
+	Name string `json:"name"`
+
+	// TimeIntervals the period consists of.
+	TimeIntervals []TimeIntervalSpec `json:"timeIntervals"`
+}
+
+// A TimeIntervalSpec matches the times all of its set fields match.
+type TimeIntervalSpec struct {
+	// Times of the day, e.g. 09:00 to 17:00.
+	// +optional
+	Times []TimeRange `json:"times,omitempty"`
+
+	// Weekdays or ranges of weekdays, e.g. monday:friday.
+	// +optional
+	Weekdays []string `json:"weekdays,omitempty"`
+
+	// DaysOfMonth or ranges of days of the month, e.g. 1:5 or -1.
+	// +optional
+	DaysOfMonth []string `json:"daysOfMonth,omitempty"`
+
+	// Months or ranges of months, e.g. january:march or 1:3.
+	// +optional
+	Months []string `json:"months,omitempty"`
+
+	// Years or ranges of years, e.g. 2023:2025.
+	// +optional
+	Years []string `json:"years,omitempty"`
+
+	// Location is the time zone of the times, e.g. Europe/Zurich.
+	// +optional
+	Location string `json:"location,omitempty"`
+}
+
+// A TimeRange is a range of time of the day.
+type TimeRange struct {
+	// StartTime of the range, e.g. 09:00.
+	StartTime string `json:"startTime"`
+
+	// EndTime of the range, e.g. 17:00.
+	EndTime string `json:"endTime"`
+}
+
+// A ConfigValid condition reports whether the configuration and template
+// files of an AlertManagerConfiguration passed validation before they were
+// pushed.
+const (
+	TypeConfigValid xpv1.ConditionType = "ConfigValid"
+
+	ReasonValid           xpv1.ConditionReason = "Valid"
+	ReasonInvalidConfig   xpv1.ConditionReason = "InvalidConfig"
+	ReasonInvalidTemplate xpv1.ConditionReason = "InvalidTemplate"
+)
+
+// ConfigValid returns a condition that indicates the configuration and
+// template files passed validation.
+func ConfigValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConfigValid,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonValid,
+	}
+}
+
+// InvalidConfig returns a condition that indicates the configuration was
+// rejected by the config loader of the Alertmanager.
+func InvalidConfig(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConfigValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidConfig,
+		Message:            err.Error(),
+	}
+}
+
+// InvalidTemplate returns a condition that indicates a template file could
+// not be compiled.
+func InvalidTemplate(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConfigValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidTemplate,
+		Message:            err.Error(),
+	}
+}
+
+// A Conflict condition reports whether another AlertManagerConfiguration of
+// the same tenant blocks an AlertManagerConfiguration. Every tenant has a
+// single Alertmanager configuration, which is managed by the oldest
+// AlertManagerConfiguration of the tenant.
+const (
+	TypeConflict xpv1.ConditionType = "Conflict"
+
+	ReasonTenantConflict xpv1.ConditionReason = "TenantConflict"
+	ReasonNoConflict     xpv1.ConditionReason = "NoConflict"
+)
+
+// TenantConflict returns a condition that indicates another
+// AlertManagerConfiguration manages the configuration of the tenant.
+func TenantConflict(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConflict,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTenantConflict,
+		Message:            msg,
+	}
+}
+
+// NoConflict returns a condition that indicates the AlertManagerConfiguration
+// manages the configuration of its tenant.
+func NoConflict() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConflict,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoConflict,
+	}
 }
 
 // AlertManagerConfigurationObservation are the observable fields of an AlertManagerConfiguration.
 type AlertManagerConfigurationObservation struct {
-	Status    string `json:"status,omitempty"`
-	Data      string `json:"data,omitempty"`
+	// Status of the last observation of the state of the Alertmanager:
+	// success, or error if one of its endpoints could not be queried.
+	Status string `json:"status,omitempty"`
+
+	// Deprecated: Data is not written. The state of the Alertmanager is
+	// reported by the other fields.
+	Data string `json:"data,omitempty"`
+
+	// Deprecated: ErrorType is not written. Error describes why the state of
+	// the Alertmanager could not be observed.
 	ErrorType string `json:"errorType,omitempty"`
-	Error     string `json:"error,omitempty"`
+
+	// Error describes why the state of the Alertmanager could not be
+	// observed, if it could not.
+	Error string `json:"error,omitempty"`
+
+	// ConfigHash is the SHA-256 hash of the configuration the Alertmanager of
+	// the tenant runs, with its secrets redacted.
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// ConfigApplied is true if the Alertmanager runs the configuration of the
+	// resource.
+	ConfigApplied bool `json:"configApplied,omitempty"`
+
+	// ClusterStatus is the state of the cluster of the Alertmanager: ready,
+	// settling or disabled.
+	ClusterStatus string `json:"clusterStatus,omitempty"`
+
+	// ClusterPeers are the peers of the cluster of the Alertmanager.
+	ClusterPeers []ClusterPeer `json:"clusterPeers,omitempty"`
+
+	// Receivers are the names of the receivers of the configuration the
+	// Alertmanager runs.
+	Receivers []string `json:"receivers,omitempty"`
+
+	// ActiveAlerts is the number of alerts that are neither silenced nor
+	// inhibited. Only the first 1000 alerts are counted.
+	ActiveAlerts int `json:"activeAlerts,omitempty"`
+
+	// SuppressedAlerts is the number of silenced or inhibited alerts. Only
+	// the first 1000 alerts are counted.
+	SuppressedAlerts int `json:"suppressedAlerts,omitempty"`
+}
+
+// A ClusterPeer is a peer of the cluster of an Alertmanager.
+type ClusterPeer struct {
+	// Name of the peer.
+	Name string `json:"name"`
+
+	// Address of the peer.
+	Address string `json:"address"`
 }
 
 // A AlertManagerConfigurationSpec defines the desired state of an AlertManagerConfiguration.
@@ -63,6 +544,8 @@ type AlertManagerConfigurationStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="CONFIG-APPLIED",type="boolean",JSONPath=".status.atProvider.configApplied"
+// +kubebuilder:printcolumn:name="ACTIVE-ALERTS",type="integer",JSONPath=".status.atProvider.activeAlerts"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cortex}
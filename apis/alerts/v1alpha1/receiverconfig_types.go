@@ -0,0 +1,678 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// The notification integrations of a receiver use the fields of the alert
+// manager config file. https://prometheus.io/docs/alerting/latest/configuration/#receiver
+// Fields which read a local file, e.g. password_file, are left out, as the
+// Alertmanager of a tenant cannot read the files of the provider. Secret
+// values can be read from the secretRefs of an AlertManagerConfiguration
+// through ${name} placeholders.
+
+// An EmailConfig sends notifications by email.
+type EmailConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to false.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// To is the address notifications are sent to.
+	To string `json:"to"`
+
+	// From is the sender address. Defaults to global.smtpFrom.
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// Hello is the hostname sent to the SMTP server. Defaults to
+	// global.smtpHello.
+	// +optional
+	Hello string `json:"hello,omitempty"`
+
+	// Smarthost is the host:port of the SMTP server. Defaults to
+	// global.smtpSmarthost.
+	// +optional
+	Smarthost string `json:"smarthost,omitempty"`
+
+	// AuthUsername is the username of SMTP authentication.
+	// +optional
+	AuthUsername string `json:"auth_username,omitempty"`
+
+	// AuthPassword is the password of SMTP authentication.
+	// +optional
+	AuthPassword string `json:"auth_password,omitempty"`
+
+	// AuthSecret is the secret of CRAM-MD5 authentication.
+	// +optional
+	AuthSecret string `json:"auth_secret,omitempty"`
+
+	// AuthIdentity is the identity of PLAIN authentication.
+	// +optional
+	AuthIdentity string `json:"auth_identity,omitempty"`
+
+	// Headers of the email, e.g. Subject.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// HTML body of the email.
+	// +optional
+	HTML string `json:"html,omitempty"`
+
+	// Text body of the email.
+	// +optional
+	Text string `json:"text,omitempty"`
+
+	// RequireTLS requires STARTTLS. Defaults to global.smtpRequireTls.
+	// +optional
+	RequireTLS *bool `json:"require_tls,omitempty"`
+
+	// TLSConfig configures the TLS connection to the SMTP server.
+	// +optional
+	TLSConfig *TLSConfig `json:"tls_config,omitempty"`
+}
+
+// A PagerdutyConfig sends notifications to PagerDuty.
+type PagerdutyConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to true.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// HTTPConfig configures the HTTP client.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty"`
+
+	// RoutingKey is the integration key of the Events API v2. Exactly one of
+	// routing_key and service_key must be set.
+	// +optional
+	RoutingKey string `json:"routing_key,omitempty"`
+
+	// ServiceKey is the integration key of the Events API v1.
+	// +optional
+	ServiceKey string `json:"service_key,omitempty"`
+
+	// URL of the PagerDuty API. Defaults to global.pagerdutyUrl.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Client is the name of the client.
+	// +optional
+	Client string `json:"client,omitempty"`
+
+	// ClientURL is a backlink to the client.
+	// +optional
+	ClientURL string `json:"client_url,omitempty"`
+
+	// Description of the incident.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Details are arbitrary key/value pairs of the incident.
+	// +optional
+	Details map[string]string `json:"details,omitempty"`
+
+	// Images attached to the incident.
+	// +optional
+	Images []PagerdutyImage `json:"images,omitempty"`
+
+	// Links attached to the incident.
+	// +optional
+	Links []PagerdutyLink `json:"links,omitempty"`
+
+	// Severity of the incident.
+	// +optional
+	Severity string `json:"severity,omitempty"`
+
+	// Class of the event.
+	// +optional
+	Class string `json:"class,omitempty"`
+
+	// Component of the source machine that is responsible for the event.
+	// +optional
+	Component string `json:"component,omitempty"`
+
+	// Group of the source machines.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// A PagerdutyImage is an image attached to a PagerDuty incident.
+type PagerdutyImage struct {
+	// Src is the URL of the image.
+	// +optional
+	Src string `json:"src,omitempty"`
+
+	// Alt is the alternative text of the image.
+	// +optional
+	Alt string `json:"alt,omitempty"`
+
+	// Href is the URL the image links to.
+	// +optional
+	Href string `json:"href,omitempty"`
+}
+
+// A PagerdutyLink is a link attached to a PagerDuty incident.
+type PagerdutyLink struct {
+	// Href is the URL of the link.
+	// +optional
+	Href string `json:"href,omitempty"`
+
+	// Text of the link.
+	// +optional
+	Text string `json:"text,omitempty"`
+}
+
+// A SlackConfig sends notifications to Slack.
+type SlackConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to false.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// HTTPConfig configures the HTTP client.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty"`
+
+	// APIURL is the Slack webhook URL. Defaults to global.slackApiUrlSecretRef.
+	// +optional
+	APIURL string `json:"api_url,omitempty"`
+
+	// Channel or user notifications are sent to.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// +optional
+	Color string `json:"color,omitempty"`
+
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// +optional
+	TitleLink string `json:"title_link,omitempty"`
+
+	// +optional
+	Pretext string `json:"pretext,omitempty"`
+
+	// +optional
+	Text string `json:"text,omitempty"`
+
+	// +optional
+	Fields []SlackField `json:"fields,omitempty"`
+
+	// +optional
+	ShortFields bool `json:"short_fields,omitempty"`
+
+	// +optional
+	Footer string `json:"footer,omitempty"`
+
+	// +optional
+	Fallback string `json:"fallback,omitempty"`
+
+	// +optional
+	CallbackID string `json:"callback_id,omitempty"`
+
+	// +optional
+	IconEmoji string `json:"icon_emoji,omitempty"`
+
+	// +optional
+	IconURL string `json:"icon_url,omitempty"`
+
+	// +optional
+	ImageURL string `json:"image_url,omitempty"`
+
+	// +optional
+	ThumbURL string `json:"thumb_url,omitempty"`
+
+	// +optional
+	LinkNames bool `json:"link_names,omitempty"`
+
+	// +optional
+	MrkdwnIn []string `json:"mrkdwn_in,omitempty"`
+
+	// +optional
+	Actions []SlackAction `json:"actions,omitempty"`
+}
+
+// A SlackField is a field of a Slack message attachment.
+type SlackField struct {
+	Title string `json:"title"`
+
+	Value string `json:"value"`
+
+	// +optional
+	Short *bool `json:"short,omitempty"`
+}
+
+// A SlackAction is a button of a Slack message attachment.
+type SlackAction struct {
+	Type string `json:"type"`
+
+	Text string `json:"text"`
+
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// +optional
+	Style string `json:"style,omitempty"`
+
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	Confirm *SlackConfirmationField `json:"confirm,omitempty"`
+}
+
+// A SlackConfirmationField asks to confirm a SlackAction.
+type SlackConfirmationField struct {
+	Text string `json:"text"`
+
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// +optional
+	OkText string `json:"ok_text,omitempty"`
+
+	// +optional
+	DismissText string `json:"dismiss_text,omitempty"`
+}
+
+// A WebhookConfig sends notifications to a webhook.
+type WebhookConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to true.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// HTTPConfig configures the HTTP client.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty"`
+
+	// URL the notifications are posted to.
+	URL string `json:"url"`
+
+	// MaxAlerts is the maximum number of alerts of a notification. All
+	// alerts are sent if it is 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAlerts int `json:"max_alerts,omitempty"`
+}
+
+// An OpsgenieConfig sends notifications to Opsgenie.
+type OpsgenieConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to true.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// HTTPConfig configures the HTTP client.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty"`
+
+	// APIKey of the Opsgenie API. Defaults to global.opsgenieApiKeySecretRef.
+	// +optional
+	APIKey string `json:"api_key,omitempty"`
+
+	// APIURL of the Opsgenie API. Defaults to global.opsgenieApiUrl.
+	// +optional
+	APIURL string `json:"api_url,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// +optional
+	Details map[string]string `json:"details,omitempty"`
+
+	// +optional
+	Entity string `json:"entity,omitempty"`
+
+	// +optional
+	Responders []OpsgenieResponder `json:"responders,omitempty"`
+
+	// Actions is a comma separated list of actions.
+	// +optional
+	Actions string `json:"actions,omitempty"`
+
+	// Tags is a comma separated list of tags.
+	// +optional
+	Tags string `json:"tags,omitempty"`
+
+	// +optional
+	Note string `json:"note,omitempty"`
+
+	// Priority of the alert, P1 to P5.
+	// +optional
+	Priority string `json:"priority,omitempty"`
+
+	// +optional
+	UpdateAlerts bool `json:"update_alerts,omitempty"`
+}
+
+// An OpsgenieResponder is responsible for an Opsgenie alert. One of id, name
+// and username must be set.
+type OpsgenieResponder struct {
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Type of the responder, e.g. team or user.
+	Type string `json:"type"`
+}
+
+// A WechatConfig sends notifications to WeChat.
+type WechatConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to false.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// HTTPConfig configures the HTTP client.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty"`
+
+	// +optional
+	APISecret string `json:"api_secret,omitempty"`
+
+	// +optional
+	APIURL string `json:"api_url,omitempty"`
+
+	// +optional
+	CorpID string `json:"corp_id,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	MessageType string `json:"message_type,omitempty"`
+
+	// +optional
+	AgentID string `json:"agent_id,omitempty"`
+
+	// +optional
+	ToUser string `json:"to_user,omitempty"`
+
+	// +optional
+	ToParty string `json:"to_party,omitempty"`
+
+	// +optional
+	ToTag string `json:"to_tag,omitempty"`
+}
+
+// A PushoverConfig sends notifications to Pushover.
+type PushoverConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to true.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// HTTPConfig configures the HTTP client.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty"`
+
+	// UserKey is the key of the recipient.
+	UserKey string `json:"user_key"`
+
+	// Token of the application.
+	Token string `json:"token"`
+
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// +optional
+	URLTitle string `json:"url_title,omitempty"`
+
+	// +optional
+	Sound string `json:"sound,omitempty"`
+
+	// +optional
+	Priority string `json:"priority,omitempty"`
+
+	// Retry is how often emergency notifications are retried, e.g. 1m.
+	// +optional
+	Retry string `json:"retry,omitempty"`
+
+	// Expire is how long emergency notifications are retried, e.g. 1h.
+	// +optional
+	Expire string `json:"expire,omitempty"`
+
+	// +optional
+	HTML bool `json:"html,omitempty"`
+}
+
+// A VictoropsConfig sends notifications to VictorOps.
+type VictoropsConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to true.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// HTTPConfig configures the HTTP client.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty"`
+
+	// APIKey of the VictorOps API. Defaults to
+	// global.victoropsApiKeySecretRef.
+	// +optional
+	APIKey string `json:"api_key,omitempty"`
+
+	// APIURL of the VictorOps API. Defaults to global.victoropsApiUrl.
+	// +optional
+	APIURL string `json:"api_url,omitempty"`
+
+	// RoutingKey of the alerts.
+	RoutingKey string `json:"routing_key"`
+
+	// +optional
+	MessageType string `json:"message_type,omitempty"`
+
+	// +optional
+	StateMessage string `json:"state_message,omitempty"`
+
+	// +optional
+	EntityDisplayName string `json:"entity_display_name,omitempty"`
+
+	// +optional
+	MonitoringTool string `json:"monitoring_tool,omitempty"`
+
+	// +optional
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
+}
+
+// An SNSConfig sends notifications to Amazon SNS.
+type SNSConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to true.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// HTTPConfig configures the HTTP client.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty"`
+
+	// +optional
+	APIURL string `json:"api_url,omitempty"`
+
+	// Sigv4 configures the AWS Signature Verification 4 of requests.
+	// +optional
+	Sigv4 *SigV4Config `json:"sigv4,omitempty"`
+
+	// TopicARN of the topic notifications are published to. One of
+	// topic_arn, phone_number and target_arn must be set.
+	// +optional
+	TopicARN string `json:"topic_arn,omitempty"`
+
+	// +optional
+	PhoneNumber string `json:"phone_number,omitempty"`
+
+	// +optional
+	TargetARN string `json:"target_arn,omitempty"`
+
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// A SigV4Config configures the AWS Signature Verification 4 of requests.
+type SigV4Config struct {
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// +optional
+	AccessKey string `json:"access_key,omitempty"`
+
+	// +optional
+	SecretKey string `json:"secret_key,omitempty"`
+
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
+	// +optional
+	RoleARN string `json:"role_arn,omitempty"`
+}
+
+// A TelegramConfig sends notifications to Telegram.
+type TelegramConfig struct {
+	// SendResolved notifies about resolved alerts. Defaults to true.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty"`
+
+	// HTTPConfig configures the HTTP client.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty"`
+
+	// APIURL of the Telegram API. Defaults to global.telegramApiUrl.
+	// +optional
+	APIURL string `json:"api_url,omitempty"`
+
+	// BotToken is the token of the Telegram bot.
+	BotToken string `json:"bot_token"`
+
+	// ChatID is the ID of the chat notifications are sent to.
+	ChatID int64 `json:"chat_id"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	DisableNotifications bool `json:"disable_notifications,omitempty"`
+
+	// ParseMode of the message, e.g. MarkdownV2 or HTML.
+	// +optional
+	ParseMode string `json:"parse_mode,omitempty"`
+}
+
+// An HTTPConfig configures the HTTP client of a notification integration.
+type HTTPConfig struct {
+	// BasicAuth sets the Authorization header with a username and password.
+	// +optional
+	BasicAuth *BasicAuth `json:"basic_auth,omitempty"`
+
+	// Authorization sets the Authorization header with a type and
+	// credentials.
+	// +optional
+	Authorization *Authorization `json:"authorization,omitempty"`
+
+	// OAuth2 authenticates with the client credentials grant.
+	// +optional
+	OAuth2 *OAuth2 `json:"oauth2,omitempty"`
+
+	// BearerToken sets the Authorization header with a bearer token.
+	// +optional
+	BearerToken string `json:"bearer_token,omitempty"`
+
+	// TLSConfig configures TLS connections.
+	// +optional
+	TLSConfig *TLSConfig `json:"tls_config,omitempty"`
+
+	// ProxyURL is the URL of an HTTP proxy.
+	// +optional
+	ProxyURL string `json:"proxy_url,omitempty"`
+
+	// FollowRedirects follows HTTP 3xx redirects. Defaults to true.
+	// +optional
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+
+	// EnableHTTP2 enables HTTP/2. Defaults to true.
+	// +optional
+	EnableHTTP2 *bool `json:"enable_http2,omitempty"`
+}
+
+// BasicAuth are the credentials of HTTP basic authentication.
+type BasicAuth struct {
+	Username string `json:"username"`
+
+	// +optional
+	Password string `json:"password,omitempty"`
+}
+
+// Authorization are the type and credentials of the Authorization header.
+type Authorization struct {
+	// Type of the credentials. Defaults to Bearer.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// +optional
+	Credentials string `json:"credentials,omitempty"`
+}
+
+// OAuth2 configures the OAuth 2.0 client credentials grant.
+type OAuth2 struct {
+	ClientID string `json:"client_id"`
+
+	ClientSecret string `json:"client_secret"`
+
+	TokenURL string `json:"token_url"`
+
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// +optional
+	EndpointParams map[string]string `json:"endpoint_params,omitempty"`
+}
+
+// A TLSConfig configures TLS connections.
+type TLSConfig struct {
+	// ServerName is used to verify the hostname of the server.
+	// +optional
+	ServerName string `json:"server_name,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the server
+	// certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty"`
+
+	// MinVersion is the minimum TLS version, e.g. TLS12.
+	// +optional
+	MinVersion string `json:"min_version,omitempty"`
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SilenceParameters are the configurable fields of a Silence.
+type SilenceParameters struct {
+	// Matchers select the alerts that are silenced.
+	// +kubebuilder:validation:MinItems=1
+	Matchers []Matcher `json:"matchers"`
+
+	// StartsAt is the time the silence starts. Defaults to the time the
+	// silence is created.
+	// +optional
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+
+	// EndsAt is the time the silence ends.
+	EndsAt metav1.Time `json:"endsAt"`
+
+	// CreatedBy names the author of the silence.
+	CreatedBy string `json:"createdBy"`
+
+	// Comment describes why the alerts are silenced.
+	Comment string `json:"comment"`
+}
+
+// A Matcher matches the value of a label of an alert.
+type Matcher struct {
+	// Name of the label.
+	Name string `json:"name"`
+
+	// Value of the label, or a regular expression if isRegex is true.
+	Value string `json:"value"`
+
+	// IsRegex matches the value as a regular expression.
+	// +optional
+	IsRegex bool `json:"isRegex,omitempty"`
+
+	// IsEqual matches alerts whose label matches the value if true and alerts
+	// whose label does not match the value if false.
+	// +kubebuilder:default=true
+	// +optional
+	IsEqual *bool `json:"isEqual,omitempty"`
+}
+
+// SilenceObservation are the observable fields of a Silence.
+type SilenceObservation struct {
+	// State of the silence, one of pending, active or expired.
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// StartsAt is the time the silence started or starts.
+	// +optional
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+
+	// EndsAt is the time the silence ended or ends.
+	// +optional
+	EndsAt *metav1.Time `json:"endsAt,omitempty"`
+
+	// UpdatedAt is the time the silence was last updated.
+	// +optional
+	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+}
+
+// A SilenceSpec defines the desired state of a Silence.
+type SilenceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SilenceParameters `json:"forProvider"`
+}
+
+// A SilenceStatus represents the observed state of a Silence.
+type SilenceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SilenceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Silence mutes the alerts of the Alertmanager of a tenant for a period of
+// time. The crossplane.io/external-name annotation holds the ID of the
+// silence. A silence expired before its end is created again, deleting the
+// Silence expires it.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="ENDS-AT",type="date",JSONPath=".spec.forProvider.endsAt"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cortex}
+type Silence struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SilenceSpec   `json:"spec"`
+	Status SilenceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SilenceList contains a list of Silence
+type SilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Silence `json:"items"`
+}
+
+// Silence type metadata.
+var (
+	SilenceKind             = reflect.TypeOf(Silence{}).Name()
+	SilenceGroupKind        = schema.GroupKind{Group: Group, Kind: SilenceKind}.String()
+	SilenceKindAPIVersion   = SilenceKind + "." + SchemeGroupVersion.String()
+	SilenceGroupVersionKind = SchemeGroupVersion.WithKind(SilenceKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Silence{}, &SilenceList{})
+}
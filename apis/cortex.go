@@ -22,6 +22,7 @@ import (
 
 	alertsv1alpha1 "github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
 	rulesv1alpha1 "github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+	tenantsv1alpha1 "github.com/swisscom/provider-cortex/apis/tenants/v1alpha1"
 	cortexv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
 )
 
@@ -31,6 +32,7 @@ func init() {
 		cortexv1alpha1.SchemeBuilder.AddToScheme,
 		rulesv1alpha1.SchemeBuilder.AddToScheme,
 		alertsv1alpha1.SchemeBuilder.AddToScheme,
+		tenantsv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -30,25 +30,115 @@ type RuleGroupParameters struct {
 	// The ruler API uses the concept of a “namespace” when creating rule groups.
 	// This is a stand in for the name of the rule file in Prometheus and rule
 	// groups must be named uniquely within a namespace.
+	// Changing the namespace moves the rule group to the new namespace.
 	// This property is required.
-	// +immutable
 	Namespace string `json:"namespace"`
 
+	RuleGroupSettings `json:",inline"`
+
+	// Recording and alerting rules exist in a rule group. Rules within a group
+	// are run sequentially at a regular interval, with the same evaluation
+	// time.
+	// https://prometheus.io/docs/prometheus/latest/configuration/recording_rules/#recording-rules
+	// +optional
+	Rules []RuleNode `json:"rules,omitempty"`
+
+	// RulesFrom lists ConfigMap and Secret keys holding rules in the Prometheus
+	// rule file format, either a single rule group or a list of rules. Their
+	// rules are appended to the inline and template rules in the order they
+	// are listed.
+	// Only the rules of a rule group are used.
+	// +optional
+	RulesFrom []RulesSource `json:"rulesFrom,omitempty"`
+
+	// TemplateRef references a RuleTemplate whose rendered rules are
+	// appended to the inline rules, before the rules of rulesFrom.
+	// +optional
+	TemplateRef *RuleTemplateReference `json:"templateRef,omitempty"`
+}
+
+// RuleGroupSettings are the fields of a rule group besides its name and its
+// rules.
+type RuleGroupSettings struct {
 	// How often rules in the group are evaluated.
 	// +optional
 	Interval *string `json:"interval,omitempty"`
 
 	// Limit the number of alerts an alerting rule and series a recording
-	// rule can produce.
+	// rule can produce. 0 is no limit.
+	// +kubebuilder:validation:Minimum=0
 	// +optional
-	// Limit    *int           `json:"limit,omitempty"`
+	Limit *int `json:"limit,omitempty"`
 
-	// Recording and alerting rules exist in a rule group. Rules within a group
-	// are run sequentially at a regular interval, with the same evaluation
-	// time.
-	// https://prometheus.io/docs/prometheus/latest/configuration/recording_rules/#recording-rules
-	// This property is required.
-	Rules []RuleNode `json:"rules"`
+	// Tenants whose series are queried when the rules of the group are
+	// evaluated. Requires the mimir backend with tenant federation enabled on
+	// the ruler.
+	// +optional
+	SourceTenants []string `json:"sourceTenants,omitempty"`
+
+	// How long the evaluation of the group is delayed to wait for late
+	// samples. Deprecated by Mimir in favour of queryOffset. Requires the
+	// mimir backend.
+	// +optional
+	EvaluationDelay *string `json:"evaluationDelay,omitempty"`
+
+	// How far in the past the queries of the group are evaluated. Requires
+	// the mimir backend.
+	// +optional
+	QueryOffset *string `json:"queryOffset,omitempty"`
+
+	// Aligns the evaluation of the group to multiples of its interval.
+	// Requires the mimir backend.
+	// +optional
+	AlignEvaluationTimeOnInterval *bool `json:"alignEvaluationTimeOnInterval,omitempty"`
+
+	// Remote write endpoints the results of the group are forwarded to by a
+	// remote write forwarding ruler.
+	// +optional
+	RemoteWrite []RemoteWriteConfig `json:"remoteWrite,omitempty"`
+}
+
+// A RuleTemplateReference references a RuleTemplate and supplies the values
+// of its parameters.
+type RuleTemplateReference struct {
+	// Name of the RuleTemplate.
+	Name string `json:"name"`
+
+	// Values of the parameters of the template by parameter name.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// A RulesSource selects a key of a ConfigMap or a Secret holding rules.
+// Exactly one of ConfigMapKeyRef and SecretKeyRef must be set.
+type RulesSource struct {
+	// Selects a key of a ConfigMap.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// Selects a key of a Secret.
+	// +optional
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// A ConfigMapKeySelector is a reference to a key of a ConfigMap in an
+// arbitrary namespace.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// The key to select.
+	Key string `json:"key"`
+}
+
+// RemoteWriteConfig specifies a remote write endpoint. The ruler takes no
+// other settings of it, such as headers or authentication.
+type RemoteWriteConfig struct {
+	// URL of the remote write endpoint.
+	URL string `json:"url"`
 }
 
 type RuleNode struct {
@@ -84,15 +174,124 @@ type RuleNode struct {
 
 	// How long an alert will continue firing after the condition that triggered it
 	// has cleared.
-	// KeepFiringFor model.Duration    `json:"keep_firing_for,omitempty"`
+	// +optional
+	KeepFiringFor *string `json:"keepFiringFor,omitempty"`
 }
 
 // RuleGroupObservation are the observable fields of a RuleGroup.
 type RuleGroupObservation struct {
+	// Status of the last response of the rules API of the ruler, either
+	// success or error.
 	Status    string `json:"status,omitempty"`
 	Data      string `json:"data,omitempty"`
 	ErrorType string `json:"errorType,omitempty"`
 	Error     string `json:"error,omitempty"`
+
+	// LastEvaluation is the time the ruler last evaluated the rule group.
+	// +optional
+	LastEvaluation *metav1.Time `json:"lastEvaluation,omitempty"`
+
+	// EvaluationTime is how long the last evaluation of the rule group took.
+	// +optional
+	EvaluationTime string `json:"evaluationTime,omitempty"`
+
+	// Rules lists the evaluation health of every rule of the group as reported
+	// by the ruler.
+	// +optional
+	Rules []RuleObservation `json:"rules,omitempty"`
+
+	// FiringAlerts is the number of firing alerts of all alerting rules of
+	// the group.
+	// +optional
+	FiringAlerts int `json:"firingAlerts"`
+
+	// PendingAlerts is the number of pending alerts of all alerting rules of
+	// the group.
+	// +optional
+	PendingAlerts int `json:"pendingAlerts"`
+
+	// Alerts lists the pending and firing alerts of every alerting rule of the
+	// group as reported by the rules API of the ruler.
+	// +optional
+	Alerts []AlertingRuleObservation `json:"alerts,omitempty"`
+
+	// The ruler namespace the rule group was last applied to.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Drift lists the differences between the desired and the observed rule
+	// group. It is empty while the rule group is up to date.
+	// +optional
+	Drift []RuleGroupDrift `json:"drift,omitempty"`
+}
+
+// A RuleObservation is the evaluation health of a single rule.
+type RuleObservation struct {
+	// Name of the rule, i.e. the name of the alert or the recorded series.
+	Name string `json:"name"`
+
+	// Type of the rule, either alerting or recording.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Health of the rule, one of ok, err or unknown.
+	// +optional
+	Health string `json:"health,omitempty"`
+
+	// LastError is the error of the last evaluation of the rule.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// LastEvaluation is the time the rule was last evaluated.
+	// +optional
+	LastEvaluation *metav1.Time `json:"lastEvaluation,omitempty"`
+
+	// EvaluationTime is how long the last evaluation of the rule took.
+	// +optional
+	EvaluationTime string `json:"evaluationTime,omitempty"`
+}
+
+// An AlertingRuleObservation are the pending and firing alerts of a single
+// alerting rule.
+type AlertingRuleObservation struct {
+	// Name of the alerting rule.
+	Name string `json:"name"`
+
+	// Firing is the number of firing alerts of the rule.
+	Firing int `json:"firing"`
+
+	// Pending is the number of pending alerts of the rule.
+	Pending int `json:"pending"`
+
+	// Samples of the alerts of the rule, firing alerts first. At most five
+	// alerts are listed.
+	// +optional
+	Samples []AlertSample `json:"samples,omitempty"`
+}
+
+// An AlertSample is a single pending or firing alert.
+type AlertSample struct {
+	// State of the alert, either pending or firing.
+	State string `json:"state"`
+
+	// Labels of the alert.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// A RuleGroupDrift describes a single field of a rule group that differs
+// between the desired and the observed state.
+type RuleGroupDrift struct {
+	// Path of the field that differs, e.g. rules[1].expr.
+	Field string `json:"field"`
+
+	// Desired value of the field. Empty if the field is not desired.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+
+	// Observed value of the field. Empty if the field was not observed.
+	// +optional
+	Observed string `json:"observed,omitempty"`
 }
 
 // A RuleGroupSpec defines the desired state of a RuleGroup.
@@ -109,10 +308,16 @@ type RuleGroupStatus struct {
 
 // +kubebuilder:object:root=true
 
-// A RuleGroup is an example API type.
+// A RuleGroup is an example API type. The crossplane.io/external-name
+// annotation holds the name of the rule group in Cortex. It defaults to the
+// name of the RuleGroup and may use the form <namespace>/<group> to adopt an
+// existing rule group. An adopted rule group is moved if its namespace differs
+// from the namespace of the spec.
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="FIRING",type="integer",JSONPath=".status.atProvider.firingAlerts"
+// +kubebuilder:printcolumn:name="PENDING",type="integer",JSONPath=".status.atProvider.pendingAlerts"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cortex}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RuleNamespaceParameters are the configurable fields of a RuleNamespace.
+type RuleNamespaceParameters struct {
+	// Groups is the full set of rule groups of the namespace.
+	// +kubebuilder:validation:MinItems=1
+	Groups []RuleNamespaceGroup `json:"groups"`
+
+	// Prune deletes the rule groups of the namespace that are not listed in
+	// groups. Deleting the RuleNamespace deletes all rule groups of the
+	// namespace if prune is enabled and only the listed ones otherwise. This
+	// includes the rule groups of RuleGroups pushing to the same namespace,
+	// so only enable prune for a namespace the RuleNamespace has to itself.
+	// +kubebuilder:default=false
+	// +optional
+	Prune *bool `json:"prune,omitempty"`
+}
+
+// A RuleNamespaceGroup is a rule group of a RuleNamespace.
+type RuleNamespaceGroup struct {
+	// Name of the rule group. Must be unique within the namespace.
+	Name string `json:"name"`
+
+	RuleGroupSettings `json:",inline"`
+
+	// Recording and alerting rules of the group.
+	Rules []RuleNode `json:"rules"`
+}
+
+// RuleNamespaceObservation are the observable fields of a RuleNamespace.
+type RuleNamespaceObservation struct {
+	// Groups lists the names of the rule groups observed in the namespace.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// UnmanagedGroups lists the rule groups of the namespace that are not
+	// listed in groups. They are deleted if prune is enabled.
+	// +optional
+	UnmanagedGroups []string `json:"unmanagedGroups,omitempty"`
+
+	// Drift lists the differences between the desired and the observed rule
+	// groups. It is empty while the namespace is up to date.
+	// +optional
+	Drift []RuleGroupDrift `json:"drift,omitempty"`
+}
+
+// A RuleNamespaceSpec defines the desired state of a RuleNamespace.
+type RuleNamespaceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RuleNamespaceParameters `json:"forProvider"`
+}
+
+// A RuleNamespaceStatus represents the observed state of a RuleNamespace.
+type RuleNamespaceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RuleNamespaceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RuleNamespace manages all rule groups of a ruler namespace. The
+// crossplane.io/external-name annotation holds the name of the namespace in
+// Cortex. It defaults to the name of the RuleNamespace.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cortex}
+type RuleNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RuleNamespaceSpec   `json:"spec"`
+	Status RuleNamespaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RuleNamespaceList contains a list of RuleNamespace
+type RuleNamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuleNamespace `json:"items"`
+}
+
+// RuleNamespace type metadata.
+var (
+	RuleNamespaceKind             = reflect.TypeOf(RuleNamespace{}).Name()
+	RuleNamespaceGroupKind        = schema.GroupKind{Group: Group, Kind: RuleNamespaceKind}.String()
+	RuleNamespaceKindAPIVersion   = RuleNamespaceKind + "." + SchemeGroupVersion.String()
+	RuleNamespaceGroupVersionKind = SchemeGroupVersion.WithKind(RuleNamespaceKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RuleNamespace{}, &RuleNamespaceList{})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Types of RuleTemplate parameters.
+const (
+	ParameterTypeString   = "string"
+	ParameterTypeInteger  = "integer"
+	ParameterTypeNumber   = "number"
+	ParameterTypeBoolean  = "boolean"
+	ParameterTypeDuration = "duration"
+)
+
+// A RuleTemplateParameter declares a parameter of a RuleTemplate.
+type RuleTemplateParameter struct {
+	// Name of the parameter. It is referenced as ${name} in the rules of the
+	// template.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+
+	// Type of the parameter. Values are checked against the type before the
+	// template is rendered.
+	// +kubebuilder:validation:Enum=string;integer;number;boolean;duration
+	// +kubebuilder:default=string
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Description of the parameter.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Default value of the parameter. A parameter without a default value
+	// must be given a value by every RuleGroup using the template.
+	// +optional
+	Default *string `json:"default,omitempty"`
+}
+
+// A RuleTemplateSpec defines the parameters and rules of a RuleTemplate.
+type RuleTemplateSpec struct {
+	// Parameters of the template.
+	// +optional
+	Parameters []RuleTemplateParameter `json:"parameters,omitempty"`
+
+	// Rules of the template. Every ${name} in the record, alert, expr, for,
+	// keepFiringFor, labels and annotations of a rule is replaced by the
+	// value of the parameter.
+	Rules []RuleNode `json:"rules"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RuleTemplate holds parameterised rules a RuleGroup can reference. Every
+// RuleGroup using a template is rendered again when the template changes.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,cortex}
+type RuleTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RuleTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// RuleTemplateList contains a list of RuleTemplate
+type RuleTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuleTemplate `json:"items"`
+}
+
+// RuleTemplate type metadata.
+var (
+	RuleTemplateKind             = reflect.TypeOf(RuleTemplate{}).Name()
+	RuleTemplateGroupKind        = schema.GroupKind{Group: Group, Kind: RuleTemplateKind}.String()
+	RuleTemplateKindAPIVersion   = RuleTemplateKind + "." + SchemeGroupVersion.String()
+	RuleTemplateGroupVersionKind = SchemeGroupVersion.WithKind(RuleTemplateKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RuleTemplate{}, &RuleTemplateList{})
+}
@@ -22,9 +22,84 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSample) DeepCopyInto(out *AlertSample) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSample.
+func (in *AlertSample) DeepCopy() *AlertSample {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSample)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingRuleObservation) DeepCopyInto(out *AlertingRuleObservation) {
+	*out = *in
+	if in.Samples != nil {
+		in, out := &in.Samples, &out.Samples
+		*out = make([]AlertSample, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingRuleObservation.
+func (in *AlertingRuleObservation) DeepCopy() *AlertingRuleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingRuleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteWriteConfig) DeepCopyInto(out *RemoteWriteConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteConfig.
+func (in *RemoteWriteConfig) DeepCopy() *RemoteWriteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteWriteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleGroup) DeepCopyInto(out *RuleGroup) {
 	*out = *in
@@ -52,6 +127,21 @@ func (in *RuleGroup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleGroupDrift) DeepCopyInto(out *RuleGroupDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupDrift.
+func (in *RuleGroupDrift) DeepCopy() *RuleGroupDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleGroupDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleGroupList) DeepCopyInto(out *RuleGroupList) {
 	*out = *in
@@ -87,6 +177,29 @@ func (in *RuleGroupList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleGroupObservation) DeepCopyInto(out *RuleGroupObservation) {
 	*out = *in
+	if in.LastEvaluation != nil {
+		in, out := &in.LastEvaluation, &out.LastEvaluation
+		*out = (*in).DeepCopy()
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RuleObservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = make([]AlertingRuleObservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]RuleGroupDrift, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupObservation.
@@ -102,11 +215,7 @@ func (in *RuleGroupObservation) DeepCopy() *RuleGroupObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleGroupParameters) DeepCopyInto(out *RuleGroupParameters) {
 	*out = *in
-	if in.Interval != nil {
-		in, out := &in.Interval, &out.Interval
-		*out = new(string)
-		**out = **in
-	}
+	in.RuleGroupSettings.DeepCopyInto(&out.RuleGroupSettings)
 	if in.Rules != nil {
 		in, out := &in.Rules, &out.Rules
 		*out = make([]RuleNode, len(*in))
@@ -114,6 +223,18 @@ func (in *RuleGroupParameters) DeepCopyInto(out *RuleGroupParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RulesFrom != nil {
+		in, out := &in.RulesFrom, &out.RulesFrom
+		*out = make([]RulesSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(RuleTemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupParameters.
@@ -126,6 +247,56 @@ func (in *RuleGroupParameters) DeepCopy() *RuleGroupParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleGroupSettings) DeepCopyInto(out *RuleGroupSettings) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(int)
+		**out = **in
+	}
+	if in.SourceTenants != nil {
+		in, out := &in.SourceTenants, &out.SourceTenants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EvaluationDelay != nil {
+		in, out := &in.EvaluationDelay, &out.EvaluationDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.QueryOffset != nil {
+		in, out := &in.QueryOffset, &out.QueryOffset
+		*out = new(string)
+		**out = **in
+	}
+	if in.AlignEvaluationTimeOnInterval != nil {
+		in, out := &in.AlignEvaluationTimeOnInterval, &out.AlignEvaluationTimeOnInterval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]RemoteWriteConfig, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupSettings.
+func (in *RuleGroupSettings) DeepCopy() *RuleGroupSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleGroupSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleGroupSpec) DeepCopyInto(out *RuleGroupSpec) {
 	*out = *in
@@ -147,7 +318,7 @@ func (in *RuleGroupSpec) DeepCopy() *RuleGroupSpec {
 func (in *RuleGroupStatus) DeepCopyInto(out *RuleGroupStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupStatus.
@@ -160,6 +331,179 @@ func (in *RuleGroupStatus) DeepCopy() *RuleGroupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleNamespace) DeepCopyInto(out *RuleNamespace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleNamespace.
+func (in *RuleNamespace) DeepCopy() *RuleNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RuleNamespace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleNamespaceGroup) DeepCopyInto(out *RuleNamespaceGroup) {
+	*out = *in
+	in.RuleGroupSettings.DeepCopyInto(&out.RuleGroupSettings)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RuleNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleNamespaceGroup.
+func (in *RuleNamespaceGroup) DeepCopy() *RuleNamespaceGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleNamespaceGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleNamespaceList) DeepCopyInto(out *RuleNamespaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RuleNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleNamespaceList.
+func (in *RuleNamespaceList) DeepCopy() *RuleNamespaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleNamespaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RuleNamespaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleNamespaceObservation) DeepCopyInto(out *RuleNamespaceObservation) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnmanagedGroups != nil {
+		in, out := &in.UnmanagedGroups, &out.UnmanagedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]RuleGroupDrift, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleNamespaceObservation.
+func (in *RuleNamespaceObservation) DeepCopy() *RuleNamespaceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleNamespaceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleNamespaceParameters) DeepCopyInto(out *RuleNamespaceParameters) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]RuleNamespaceGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleNamespaceParameters.
+func (in *RuleNamespaceParameters) DeepCopy() *RuleNamespaceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleNamespaceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleNamespaceSpec) DeepCopyInto(out *RuleNamespaceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleNamespaceSpec.
+func (in *RuleNamespaceSpec) DeepCopy() *RuleNamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleNamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleNamespaceStatus) DeepCopyInto(out *RuleNamespaceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleNamespaceStatus.
+func (in *RuleNamespaceStatus) DeepCopy() *RuleNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleNode) DeepCopyInto(out *RuleNode) {
 	*out = *in
@@ -192,6 +536,11 @@ func (in *RuleNode) DeepCopyInto(out *RuleNode) {
 			(*out)[key] = val
 		}
 	}
+	if in.KeepFiringFor != nil {
+		in, out := &in.KeepFiringFor, &out.KeepFiringFor
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleNode.
@@ -203,3 +552,176 @@ func (in *RuleNode) DeepCopy() *RuleNode {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleObservation) DeepCopyInto(out *RuleObservation) {
+	*out = *in
+	if in.LastEvaluation != nil {
+		in, out := &in.LastEvaluation, &out.LastEvaluation
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleObservation.
+func (in *RuleObservation) DeepCopy() *RuleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTemplate) DeepCopyInto(out *RuleTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTemplate.
+func (in *RuleTemplate) DeepCopy() *RuleTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RuleTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTemplateList) DeepCopyInto(out *RuleTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RuleTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTemplateList.
+func (in *RuleTemplateList) DeepCopy() *RuleTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RuleTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTemplateParameter) DeepCopyInto(out *RuleTemplateParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTemplateParameter.
+func (in *RuleTemplateParameter) DeepCopy() *RuleTemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTemplateReference) DeepCopyInto(out *RuleTemplateReference) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTemplateReference.
+func (in *RuleTemplateReference) DeepCopy() *RuleTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTemplateSpec) DeepCopyInto(out *RuleTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]RuleTemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RuleNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTemplateSpec.
+func (in *RuleTemplateSpec) DeepCopy() *RuleTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RulesSource) DeepCopyInto(out *RulesSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RulesSource.
+func (in *RulesSource) DeepCopy() *RulesSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RulesSource)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -27,3 +27,12 @@ func (l *RuleGroupList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this RuleNamespaceList.
+func (l *RuleNamespaceList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SeriesDeletionRequestParameters are the configurable fields of a
+// SeriesDeletionRequest. A submitted request cannot be changed.
+type SeriesDeletionRequestParameters struct {
+	// Matchers are the series selectors of the series to delete, e.g.
+	// {job="app", email=~".+"}.
+	// +kubebuilder:validation:MinItems=1
+	Matchers []string `json:"matchers"`
+
+	// Start of the time range to delete. Defaults to the beginning of time.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End of the time range to delete. Defaults to the time the request is
+	// submitted.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+}
+
+// SeriesDeletionRequestObservation are the observable fields of a
+// SeriesDeletionRequest.
+type SeriesDeletionRequestObservation struct {
+	// RequestID is the ID of the request.
+	// +optional
+	RequestID string `json:"requestId,omitempty"`
+
+	// Status of the request, e.g. received, buildingPlan, deleting or
+	// processed.
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// CreatedAt is the time the request was submitted.
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// Start of the time range that is deleted.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End of the time range that is deleted.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+
+	// CancelRefused is true once the purger refused to cancel the request,
+	// e.g. because its cancel period is over.
+	// +optional
+	CancelRefused bool `json:"cancelRefused,omitempty"`
+}
+
+// A SeriesDeletionRequestSpec defines the desired state of a
+// SeriesDeletionRequest.
+type SeriesDeletionRequestSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SeriesDeletionRequestParameters `json:"forProvider"`
+}
+
+// A SeriesDeletionRequestStatus represents the observed state of a
+// SeriesDeletionRequest.
+type SeriesDeletionRequestStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SeriesDeletionRequestObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SeriesDeletionRequest deletes series of the tenant of its ProviderConfig
+// using the purger API. It is ready once the request is processed. Deleting
+// the SeriesDeletionRequest cancels the request while the purger still
+// accepts its cancellation. The crossplane.io/external-name annotation holds
+// the ID of the request and the cortex.crossplane.io/submitted annotation the
+// parameters it was submitted with. The request is never submitted again
+// while the latter is set.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cortex}
+type SeriesDeletionRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SeriesDeletionRequestSpec   `json:"spec"`
+	Status SeriesDeletionRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SeriesDeletionRequestList contains a list of SeriesDeletionRequest
+type SeriesDeletionRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SeriesDeletionRequest `json:"items"`
+}
+
+// SeriesDeletionRequest type metadata.
+var (
+	SeriesDeletionRequestKind             = reflect.TypeOf(SeriesDeletionRequest{}).Name()
+	SeriesDeletionRequestGroupKind        = schema.GroupKind{Group: Group, Kind: SeriesDeletionRequestKind}.String()
+	SeriesDeletionRequestKindAPIVersion   = SeriesDeletionRequestKind + "." + SchemeGroupVersion.String()
+	SeriesDeletionRequestGroupVersionKind = SchemeGroupVersion.WithKind(SeriesDeletionRequestKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&SeriesDeletionRequest{}, &SeriesDeletionRequestList{})
+}
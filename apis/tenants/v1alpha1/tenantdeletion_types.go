@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TenantDeletionParameters are the configurable fields of a TenantDeletion.
+// The tenant is the one of the ProviderConfig.
+type TenantDeletionParameters struct{}
+
+// TenantDeletionObservation are the observable fields of a TenantDeletion.
+type TenantDeletionObservation struct {
+	// TenantID is the tenant whose deletion was requested.
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
+
+	// BlocksDeleted is true once the compactor deleted all blocks of the
+	// tenant, which completes the deletion.
+	BlocksDeleted bool `json:"blocksDeleted"`
+}
+
+// A TenantDeletionSpec defines the desired state of a TenantDeletion.
+type TenantDeletionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TenantDeletionParameters `json:"forProvider"`
+}
+
+// A TenantDeletionStatus represents the observed state of a TenantDeletion.
+type TenantDeletionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TenantDeletionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TenantDeletion deletes all data of the tenant of its ProviderConfig using
+// the purger API. It is ready once the deletion is complete. Deleting the
+// TenantDeletion does not restore the tenant. The
+// crossplane.io/external-name annotation holds the tenant the deletion was
+// requested for. If the ProviderConfig refers to another tenant afterwards,
+// the deletion is not requested again and the TenantDeletion reports an
+// error.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TENANT",type="string",JSONPath=".status.atProvider.tenantId"
+// +kubebuilder:printcolumn:name="BLOCKS-DELETED",type="boolean",JSONPath=".status.atProvider.blocksDeleted"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cortex}
+type TenantDeletion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantDeletionSpec   `json:"spec"`
+	Status TenantDeletionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantDeletionList contains a list of TenantDeletion
+type TenantDeletionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantDeletion `json:"items"`
+}
+
+// TenantDeletion type metadata.
+var (
+	TenantDeletionKind             = reflect.TypeOf(TenantDeletion{}).Name()
+	TenantDeletionGroupKind        = schema.GroupKind{Group: Group, Kind: TenantDeletionKind}.String()
+	TenantDeletionKindAPIVersion   = TenantDeletionKind + "." + SchemeGroupVersion.String()
+	TenantDeletionGroupVersionKind = SchemeGroupVersion.WithKind(TenantDeletionKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TenantDeletion{}, &TenantDeletionList{})
+}
@@ -25,6 +25,13 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// Backends a ProviderConfig can connect to.
+const (
+	BackendCortex = "cortex"
+	BackendLoki   = "loki"
+	BackendMimir  = "mimir"
+)
+
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
 type ProviderConfigSpec struct {
 	// ID of the cortex tenant
@@ -33,6 +40,17 @@ type ProviderConfigSpec struct {
 	// Address of the cortex server
 	Address string `json:"address"`
 
+	// Backend is the kind of server at the address. The ruler API of a loki
+	// backend is served under /loki and the expressions of its rules are
+	// LogQL rather than PromQL. LogQL expressions are not validated by the
+	// provider, Loki rejects invalid ones. The ruler API of a mimir backend is
+	// served under /prometheus/config/v1/rules and supports the Mimir only
+	// fields of rule groups.
+	// +kubebuilder:validation:Enum=cortex;loki;mimir
+	// +kubebuilder:default=cortex
+	// +optional
+	Backend string `json:"backend,omitempty"`
+
 	// The keys of other cortex configuration parameters that are retrieved from Credentials secrets
 	SecretKeys CortexSecretKeys `json:"secretKeys"`
 
@@ -39,6 +39,7 @@ import (
 	"github.com/swisscom/provider-cortex/apis"
 	"github.com/swisscom/provider-cortex/apis/v1alpha1"
 	cortex "github.com/swisscom/provider-cortex/internal/controller"
+	"github.com/swisscom/provider-cortex/internal/controller/prometheusrule"
 	"github.com/swisscom/provider-cortex/internal/features"
 )
 
@@ -55,6 +56,10 @@ func main() {
 		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
 		enableManagementPolicies   = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("false").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
+
+		enablePrometheusRules          = app.Flag("enable-prometheus-rules", "Generate RuleGroups from the PrometheusRules of the Prometheus Operator. Requires the PrometheusRule CRD.").Default("false").Envar("ENABLE_PROMETHEUS_RULES").Bool()
+		prometheusRulesProviderConfig  = app.Flag("prometheus-rules-provider-config", "ProviderConfig of the RuleGroups generated from PrometheusRules.").Default("default").Envar("PROMETHEUS_RULES_PROVIDER_CONFIG").String()
+		prometheusRulesNamespaceFormat = app.Flag("prometheus-rules-namespace-format", "Go template of the ruler namespace of the RuleGroups generated from a PrometheusRule, given its .Namespace and .Name.").Default("{{ .Namespace }}-{{ .Name }}").Envar("PROMETHEUS_RULES_NAMESPACE_FORMAT").String()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -122,5 +127,14 @@ func main() {
 	}
 
 	kingpin.FatalIfError(cortex.Setup(mgr, o), "Cannot setup cortex controllers")
+
+	if *enablePrometheusRules {
+		kingpin.FatalIfError(prometheusrule.Setup(mgr, o, prometheusrule.Options{
+			ProviderConfigName: *prometheusRulesProviderConfig,
+			NamespaceFormat:    *prometheusRulesNamespaceFormat,
+		}), "Cannot setup PrometheusRule controller")
+		log.Info("PrometheusRule controller enabled", "providerConfig", *prometheusRulesProviderConfig)
+	}
+
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
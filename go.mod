@@ -17,9 +17,12 @@ require (
 
 require (
 	github.com/cortexproject/cortex-tools v0.11.2-0.20230927171007-58aa76d01708
+	github.com/prometheus/alertmanager v0.24.0
 	github.com/prometheus/common v0.42.0
 	github.com/prometheus/prometheus v1.8.2-0.20220411232225-ce6a643ee88f
 	gopkg.in/yaml.v3 v3.0.1
+	k8s.io/api v0.26.5
+	k8s.io/apiextensions-apiserver v0.26.5
 )
 
 require (
@@ -105,6 +108,8 @@ require (
 	github.com/prometheus/procfs v0.9.0 // indirect
 	github.com/rogpeppe/go-internal v1.10.0 // indirect
 	github.com/ryanuber/go-glob v1.0.0 // indirect
+	github.com/shurcooL/httpfs v0.0.0-20190707220628-8d4bc4ba7749 // indirect
+	github.com/shurcooL/vfsgen v0.0.0-20200824052919-0d455de96546 // indirect
 	github.com/sirupsen/logrus v1.9.0 // indirect
 	github.com/spf13/afero v1.8.0 // indirect
 	github.com/spf13/cobra v1.7.0 // indirect
@@ -134,8 +139,6 @@ require (
 	gopkg.in/inf.v0 v0.9.1 // indirect
 	gopkg.in/square/go-jose.v2 v2.5.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	k8s.io/api v0.26.5 // indirect
-	k8s.io/apiextensions-apiserver v0.26.5 // indirect
 	k8s.io/component-base v0.26.5 // indirect
 	k8s.io/klog/v2 v2.90.1 // indirect
 	k8s.io/kube-openapi v0.0.0-20230501164219-8b0f38b5fd1f // indirect
@@ -23,4 +23,5 @@ type AlertManagerClient interface {
 	GetAlertmanagerConfig(ctx context.Context) (string, map[string]string, error)
 	CreateAlertmanagerConfig(ctx context.Context, cfg string, templates map[string]string) error
 	DeleteAlermanagerConfig(ctx context.Context) error
+	StatusClient
 }
@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	amconfig "github.com/prometheus/alertmanager/config"
+	commoncfg "github.com/prometheus/common/config"
+)
+
+var (
+	secretType       = reflect.TypeOf(amconfig.Secret(""))
+	secretURLType    = reflect.TypeOf(amconfig.SecretURL{})
+	commonSecretType = reflect.TypeOf(commoncfg.Secret(""))
+)
+
+// ConfigsEqual reports whether two alert manager config files are
+// semantically equal. Both are parsed with the config loader of the
+// Alertmanager, so formatting, key order and defaults do not matter. If
+// either of them cannot be parsed they are compared as strings.
+func ConfigsEqual(a, b string) bool {
+	ca, err := amconfig.Load(a)
+	if err != nil {
+		return a == b
+	}
+	cb, err := amconfig.Load(b)
+	if err != nil {
+		return a == b
+	}
+
+	// The string form of a config redacts its secrets, so they are compared
+	// separately.
+	return ca.String() == cb.String() && reflect.DeepEqual(secrets(ca), secrets(cb))
+}
+
+// RunsConfig reports whether running, the configuration an Alertmanager
+// reports to run with its secrets redacted, is the alert manager config file
+// cfg. Secrets are not compared.
+func RunsConfig(cfg, running string) bool {
+	c, err := amconfig.Load(cfg)
+	if err != nil {
+		return false
+	}
+	r, err := amconfig.Load(running)
+	if err != nil {
+		return false
+	}
+	return c.String() == r.String()
+}
+
+// TemplatesEqual reports whether two sets of template files are equal except
+// for line endings and trailing whitespace.
+func TemplatesEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, ta := range a {
+		tb, ok := b[name]
+		if !ok || normalizeTemplate(ta) != normalizeTemplate(tb) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeTemplate(t string) string {
+	lines := strings.Split(strings.ReplaceAll(t, "\r\n", "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// secrets returns the values of all secrets of c in the order they appear in.
+func secrets(c *amconfig.Config) []string {
+	var out []string
+	collectSecrets(reflect.ValueOf(c), &out)
+	return out
+}
+
+func collectSecrets(v reflect.Value, out *[]string) {
+	switch v.Type() {
+	case secretType, commonSecretType:
+		*out = append(*out, v.String())
+		return
+	case secretURLType:
+		if u := v.Interface().(amconfig.SecretURL); u.URL != nil {
+			*out = append(*out, u.String())
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			collectSecrets(v.Elem(), out)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collectSecrets(v.Index(i), out)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			collectSecrets(v.MapIndex(k), out)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				collectSecrets(v.Field(i), out)
+			}
+		}
+	}
+}
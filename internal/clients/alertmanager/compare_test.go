@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigsEqual(t *testing.T) {
+	const desired = `
+global:
+  smtp_auth_password: s3cret
+route:
+  receiver: team
+  group_by: [alertname]
+receivers:
+  - name: team
+    webhook_configs:
+      - url: http://team.example.com/hook
+        send_resolved: true
+`
+
+	cases := map[string]struct {
+		reason   string
+		observed string
+		want     bool
+	}{
+		"Identical": {
+			reason:   "Identical configs should be equal.",
+			observed: desired,
+			want:     true,
+		},
+		"Reformatted": {
+			reason: "Configs differing in formatting and key order should be equal.",
+			observed: `global: {smtp_auth_password: "s3cret"}
+receivers:
+- webhook_configs:
+  - send_resolved: true
+    url: "http://team.example.com/hook"
+  name: team
+route: {group_by: ["alertname"], receiver: team}
+`,
+			want: true,
+		},
+		"DefaultsFilledIn": {
+			reason:   "Configs differing in defaults only should be equal.",
+			observed: strings.Replace(desired, "global:\n", "global:\n  resolve_timeout: 5m\n", 1) + "templates: []\n",
+			want:     true,
+		},
+		"Changed": {
+			reason:   "Configs differing in a value should not be equal.",
+			observed: `{global: {smtp_auth_password: s3cret}, route: {receiver: team, group_by: [cluster]}, receivers: [{name: team, webhook_configs: [{url: "http://team.example.com/hook"}]}]}`,
+			want:     false,
+		},
+		"SecretChanged": {
+			reason:   "Configs differing in a secret should not be equal although secrets are redacted.",
+			observed: `{global: {smtp_auth_password: other}, route: {receiver: team, group_by: [alertname]}, receivers: [{name: team, webhook_configs: [{url: "http://team.example.com/hook", send_resolved: true}]}]}`,
+			want:     false,
+		},
+		"Unparsable": {
+			reason:   "A config that cannot be parsed should not be equal to a different one.",
+			observed: "route: [",
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ConfigsEqual(desired, tc.observed); got != tc.want {
+				t.Errorf("\n%s\nConfigsEqual(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTemplatesEqual(t *testing.T) {
+	desired := map[string]string{"default": "{{ define \"title\" }}\n  Alert\n{{ end }}\n"}
+
+	cases := map[string]struct {
+		reason   string
+		observed map[string]string
+		want     bool
+	}{
+		"Whitespace": {
+			reason:   "Templates differing in line endings and trailing whitespace should be equal.",
+			observed: map[string]string{"default": "{{ define \"title\" }}  \r\n  Alert\r\n{{ end }}"},
+			want:     true,
+		},
+		"Changed": {
+			reason:   "Templates differing in content should not be equal.",
+			observed: map[string]string{"default": "{{ define \"title\" }}\n  Alarm\n{{ end }}\n"},
+			want:     false,
+		},
+		"Renamed": {
+			reason:   "Template files with different names should not be equal.",
+			observed: map[string]string{"other": desired["default"]},
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := TemplatesEqual(desired, tc.observed); got != tc.want {
+				t.Errorf("\n%s\nTemplatesEqual(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+)
+
+const (
+	// DefaultEnforcedMatcherLabel is the label of the enforced matcher of
+	// AlertmanagerRoutes if the AlertManagerConfiguration does not set one.
+	DefaultEnforcedMatcherLabel = "namespace"
+
+	errParseBaseConfig = "cannot parse configuration"
+	errNoRootRoute     = "configuration has no route"
+
+	errFmtDuplicateReceiver = "AlertmanagerReceiver %s has the name of a receiver of the configuration"
+	errFmtForeignReceiver   = "AlertmanagerRoute %s refers to receiver %s, which is neither a receiver of its team nor of the configuration"
+)
+
+// A Rejection is an AlertmanagerRoute or AlertmanagerReceiver that was left
+// out of the configuration, and the reason why.
+type Rejection struct {
+	Object client.Object
+	Err    error
+}
+
+// MergeConfig adds the routes and receivers of teams to the alert manager
+// config file base. Every route becomes a child of the root route in front of
+// the routes of base, in the order of their names. It is constrained by the
+// matcher label="value", where value is the namespace of the claim of the
+// AlertmanagerRoute or its name, and the routes after it are always matched
+// too. Every receiver is named as TeamReceiverName returns. A route can only
+// refer to the receivers of base and to the AlertmanagerReceivers of its own
+// team, by their names. Invalid routes and receivers are left out and
+// returned as rejections rather than failing the whole configuration, so
+// that one team cannot block the configuration of all others.
+func MergeConfig(base string, label string, routes []v1alpha1.AlertmanagerRoute, receivers []v1alpha1.AlertmanagerReceiver) (string, []Rejection, error) {
+	if len(routes) == 0 && len(receivers) == 0 {
+		return base, nil, nil
+	}
+	if label == "" {
+		label = DefaultEnforcedMatcherLabel
+	}
+
+	cfg := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(base), &cfg); err != nil {
+		return "", nil, errors.Wrap(err, errParseBaseConfig)
+	}
+	root, ok := cfg["route"].(map[string]interface{})
+	if !ok {
+		return "", nil, errors.New(errNoRootRoute)
+	}
+
+	existing, _ := cfg["receivers"].([]interface{})
+	names := map[string]bool{}
+	for _, rcv := range existing {
+		if m, ok := rcv.(map[string]interface{}); ok {
+			names[fmt.Sprint(m["name"])] = true
+		}
+	}
+
+	var rejected []Rejection
+
+	// The receivers of every team by the names its routes use for them. Only
+	// the receivers that are merged can be referred to.
+	teams := map[string]map[string]string{}
+	sort.Slice(receivers, func(i, j int) bool { return receivers[i].GetName() < receivers[j].GetName() })
+	for i := range receivers {
+		rcv := &receivers[i]
+		generic, err := mergeReceiver(rcv, names)
+		if err != nil {
+			rejected = append(rejected, Rejection{Object: rcv, Err: err})
+			continue
+		}
+		existing = append(existing, generic)
+
+		team := claimNamespace(rcv)
+		if teams[team] == nil {
+			teams[team] = map[string]string{}
+		}
+		teams[team][rcv.GetName()] = TeamReceiverName(rcv)
+	}
+	cfg["receivers"] = existing
+
+	sort.Slice(routes, func(i, j int) bool { return routes[i].GetName() < routes[j].GetName() })
+	var children []interface{}
+	for i := range routes {
+		rt := &routes[i]
+		child, err := mergeRoute(rt, label, func(name string) (string, bool) {
+			if n, ok := teams[claimNamespace(rt)][name]; ok {
+				return n, true
+			}
+			return name, names[name]
+		})
+		if err != nil {
+			rejected = append(rejected, Rejection{Object: rt, Err: err})
+			continue
+		}
+		children = append(children, child)
+	}
+	if existing, ok := root["routes"].([]interface{}); ok {
+		children = append(children, existing...)
+	}
+	if len(children) > 0 {
+		root["routes"] = children
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return "", nil, errors.Wrap(err, errMarshalConfig)
+	}
+	if err := enc.Close(); err != nil {
+		return "", nil, errors.Wrap(err, errMarshalConfig)
+	}
+	return buf.String(), rejected, nil
+}
+
+// mergeReceiver returns the receiver of an AlertmanagerReceiver as it is
+// added to a configuration whose receivers are names.
+func mergeReceiver(rcv *v1alpha1.AlertmanagerReceiver, names map[string]bool) (interface{}, error) {
+	if names[TeamReceiverName(rcv)] {
+		return nil, errors.Errorf(errFmtDuplicateReceiver, rcv.GetName())
+	}
+	r, err := convertReceiver(v1alpha1.Receiver{Name: TeamReceiverName(rcv), ReceiverConfigs: rcv.Spec.ReceiverConfigs}, fmt.Sprintf("AlertmanagerReceiver %s", rcv.GetName()))
+	if err != nil {
+		return nil, err
+	}
+	return toGeneric(r)
+}
+
+// mergeRoute returns the route of an AlertmanagerRoute as it is added to a
+// configuration, constrained by the enforced matcher label and with its
+// receivers named as resolve returns.
+func mergeRoute(rt *v1alpha1.AlertmanagerRoute, label string, resolve func(string) (string, bool)) (interface{}, error) {
+	r := convertRoute(rt.Spec.Route)
+	if unknown := scopeReceivers(r, resolve); unknown != "" {
+		return nil, errors.Errorf(errFmtForeignReceiver, rt.GetName(), unknown)
+	}
+	r.Matchers = append([]string{fmt.Sprintf("%s=%q", label, EnforcedMatcherValue(rt))}, r.Matchers...)
+	r.Continue = true
+	return toGeneric(r)
+}
+
+// TeamReceiverName returns the name of the receiver of an AlertmanagerReceiver in
+// the configuration: its name, prefixed with the namespace of its claim if it
+// has one. Names of Kubernetes objects cannot contain a slash, so the
+// receivers of different teams cannot have the same name.
+func TeamReceiverName(rcv *v1alpha1.AlertmanagerReceiver) string {
+	if ns := claimNamespace(rcv); ns != "" {
+		return ns + "/" + rcv.GetName()
+	}
+	return rcv.GetName()
+}
+
+// claimNamespace returns the namespace of the claim of o, which identifies
+// the team of an AlertmanagerRoute or AlertmanagerReceiver. It is empty for
+// the ones created without a claim.
+func claimNamespace(o metav1.Object) string {
+	return o.GetLabels()[v1alpha1.LabelKeyClaimNamespace]
+}
+
+// scopeReceivers replaces the receivers r and its child routes refer to with
+// the names resolve returns for them. It returns the first receiver resolve
+// does not know, or an empty string if it knows all of them.
+func scopeReceivers(r *route, resolve func(string) (string, bool)) string {
+	if r.Receiver != "" {
+		name, ok := resolve(r.Receiver)
+		if !ok {
+			return r.Receiver
+		}
+		r.Receiver = name
+	}
+	for _, c := range r.Routes {
+		if unknown := scopeReceivers(c, resolve); unknown != "" {
+			return unknown
+		}
+	}
+	return ""
+}
+
+// EnforcedMatcherValue returns the value of the enforced matcher of an
+// AlertmanagerRoute: the namespace of its claim, or its name if it has none.
+func EnforcedMatcherValue(rt *v1alpha1.AlertmanagerRoute) string {
+	if ns := rt.GetLabels()[v1alpha1.LabelKeyClaimNamespace]; ns != "" {
+		return ns
+	}
+	return rt.GetName()
+}
+
+// toGeneric converts v into the maps and slices it is marshalled as, so it
+// can be added to a parsed config file.
+func toGeneric(v interface{}) (interface{}, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalConfig)
+	}
+	var out interface{}
+	if err := yaml.Unmarshal(b, &out); err != nil {
+		return nil, errors.Wrap(err, errMarshalConfig)
+	}
+	return out, nil
+}
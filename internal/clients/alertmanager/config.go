@@ -0,0 +1,336 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+)
+
+const (
+	errConfigNotSet  = "one of alertmanager_config and config must be set"
+	errConfigBothSet = "alertmanager_config and config are mutually exclusive"
+	errMarshalConfig = "cannot marshal config"
+)
+
+// Placeholders of the values of the Secret keys the global parameters select.
+// They contain a dot, which the names of secretRefs cannot, so they cannot
+// clash with the placeholders of secretRefs.
+const (
+	placeholderSMTPAuthPassword = "global.smtpAuthPassword"
+	placeholderSlackAPIURL      = "global.slackApiUrl"
+	placeholderOpsgenieAPIKey   = "global.opsgenieApiKey"
+	placeholderVictoropsAPIKey  = "global.victoropsApiKey"
+)
+
+// config is the alert manager config file the structured form of an
+// AlertManagerConfiguration is rendered into.
+type config struct {
+	Global        *globalConfig  `yaml:"global,omitempty"`
+	Route         *route         `yaml:"route"`
+	Receivers     []receiver     `yaml:"receivers"`
+	InhibitRules  []inhibitRule  `yaml:"inhibit_rules,omitempty"`
+	TimeIntervals []timeInterval `yaml:"time_intervals,omitempty"`
+	Templates     []string       `yaml:"templates,omitempty"`
+}
+
+type globalConfig struct {
+	ResolveTimeout   *string `yaml:"resolve_timeout,omitempty"`
+	SMTPFrom         string  `yaml:"smtp_from,omitempty"`
+	SMTPSmarthost    string  `yaml:"smtp_smarthost,omitempty"`
+	SMTPHello        string  `yaml:"smtp_hello,omitempty"`
+	SMTPAuthUsername string  `yaml:"smtp_auth_username,omitempty"`
+	SMTPAuthPassword string  `yaml:"smtp_auth_password,omitempty"`
+	SMTPAuthIdentity string  `yaml:"smtp_auth_identity,omitempty"`
+	SMTPRequireTLS   *bool   `yaml:"smtp_require_tls,omitempty"`
+	SlackAPIURL      string  `yaml:"slack_api_url,omitempty"`
+	PagerdutyURL     string  `yaml:"pagerduty_url,omitempty"`
+	OpsgenieAPIURL   string  `yaml:"opsgenie_api_url,omitempty"`
+	OpsgenieAPIKey   string  `yaml:"opsgenie_api_key,omitempty"`
+	VictoropsAPIURL  string  `yaml:"victorops_api_url,omitempty"`
+	VictoropsAPIKey  string  `yaml:"victorops_api_key,omitempty"`
+	TelegramAPIURL   string  `yaml:"telegram_api_url,omitempty"`
+}
+
+type route struct {
+	Receiver            string   `yaml:"receiver,omitempty"`
+	GroupBy             []string `yaml:"group_by,omitempty"`
+	Continue            bool     `yaml:"continue,omitempty"`
+	Matchers            []string `yaml:"matchers,omitempty"`
+	GroupWait           *string  `yaml:"group_wait,omitempty"`
+	GroupInterval       *string  `yaml:"group_interval,omitempty"`
+	RepeatInterval      *string  `yaml:"repeat_interval,omitempty"`
+	MuteTimeIntervals   []string `yaml:"mute_time_intervals,omitempty"`
+	ActiveTimeIntervals []string `yaml:"active_time_intervals,omitempty"`
+	Routes              []*route `yaml:"routes,omitempty"`
+}
+
+type receiver struct {
+	Name             string        `yaml:"name"`
+	EmailConfigs     []interface{} `yaml:"email_configs,omitempty"`
+	PagerdutyConfigs []interface{} `yaml:"pagerduty_configs,omitempty"`
+	SlackConfigs     []interface{} `yaml:"slack_configs,omitempty"`
+	WebhookConfigs   []interface{} `yaml:"webhook_configs,omitempty"`
+	OpsgenieConfigs  []interface{} `yaml:"opsgenie_configs,omitempty"`
+	WechatConfigs    []interface{} `yaml:"wechat_configs,omitempty"`
+	PushoverConfigs  []interface{} `yaml:"pushover_configs,omitempty"`
+	VictoropsConfigs []interface{} `yaml:"victorops_configs,omitempty"`
+	SNSConfigs       []interface{} `yaml:"sns_configs,omitempty"`
+	TelegramConfigs  []interface{} `yaml:"telegram_configs,omitempty"`
+}
+
+type inhibitRule struct {
+	SourceMatchers []string `yaml:"source_matchers,omitempty"`
+	TargetMatchers []string `yaml:"target_matchers,omitempty"`
+	Equal          []string `yaml:"equal,omitempty"`
+}
+
+type timeInterval struct {
+	Name          string             `yaml:"name"`
+	TimeIntervals []timeIntervalSpec `yaml:"time_intervals"`
+}
+
+type timeIntervalSpec struct {
+	Times       []timeRange `yaml:"times,omitempty"`
+	Weekdays    []string    `yaml:"weekdays,omitempty"`
+	DaysOfMonth []string    `yaml:"days_of_month,omitempty"`
+	Months      []string    `yaml:"months,omitempty"`
+	Years       []string    `yaml:"years,omitempty"`
+	Location    string      `yaml:"location,omitempty"`
+}
+
+type timeRange struct {
+	StartTime string `yaml:"start_time"`
+	EndTime   string `yaml:"end_time"`
+}
+
+// DesiredConfig returns the alert manager config file of p: either its
+// alertmanager_config as is, or its structured config rendered into YAML.
+// Exactly one of them has to be set.
+func DesiredConfig(p v1alpha1.AlertManagerConfigurationParameters) (string, error) {
+	switch {
+	case p.AlertmanagerConfig != "" && p.Config != nil:
+		return "", errors.New(errConfigBothSet)
+	case p.Config != nil:
+		return RenderConfig(p.Config)
+	case p.AlertmanagerConfig != "":
+		return p.AlertmanagerConfig, nil
+	default:
+		return "", errors.New(errConfigNotSet)
+	}
+}
+
+// RenderConfig renders the structured form of an alert manager configuration
+// into an alert manager config file. The fields are written in a fixed order,
+// so equal configurations render into equal files.
+func RenderConfig(in *v1alpha1.AlertmanagerConfig) (string, error) {
+	out := config{
+		Global:    convertGlobal(in.Global),
+		Route:     convertRoute(in.Route),
+		Templates: in.Templates,
+	}
+	for i, rcv := range in.Receivers {
+		c, err := convertReceiver(rcv, fmt.Sprintf("receivers[%d]", i))
+		if err != nil {
+			return "", err
+		}
+		out.Receivers = append(out.Receivers, c)
+	}
+	for _, ir := range in.InhibitRules {
+		out.InhibitRules = append(out.InhibitRules, inhibitRule{
+			SourceMatchers: ir.SourceMatchers,
+			TargetMatchers: ir.TargetMatchers,
+			Equal:          ir.Equal,
+		})
+	}
+	for _, ti := range in.TimeIntervals {
+		out.TimeIntervals = append(out.TimeIntervals, convertTimeInterval(ti))
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&out); err != nil {
+		return "", errors.Wrap(err, errMarshalConfig)
+	}
+	if err := enc.Close(); err != nil {
+		return "", errors.Wrap(err, errMarshalConfig)
+	}
+	return buf.String(), nil
+}
+
+// GlobalSecretRefs returns the Secret keys the global parameters of in
+// select, named by the placeholders RenderConfig writes in place of their
+// values.
+func GlobalSecretRefs(in *v1alpha1.AlertmanagerConfig) []v1alpha1.ConfigSecretRef {
+	if in == nil || in.Global == nil {
+		return nil
+	}
+	var refs []v1alpha1.ConfigSecretRef
+	for _, r := range []struct {
+		name string
+		ref  *xpv1.SecretKeySelector
+	}{
+		{name: placeholderSMTPAuthPassword, ref: in.Global.SMTPAuthPasswordSecretRef},
+		{name: placeholderSlackAPIURL, ref: in.Global.SlackAPIURLSecretRef},
+		{name: placeholderOpsgenieAPIKey, ref: in.Global.OpsgenieAPIKeySecretRef},
+		{name: placeholderVictoropsAPIKey, ref: in.Global.VictoropsAPIKeySecretRef},
+	} {
+		if r.ref != nil {
+			refs = append(refs, v1alpha1.ConfigSecretRef{Name: r.name, SecretKeyRef: *r.ref})
+		}
+	}
+	return refs
+}
+
+// convertGlobal converts in. Its secret values are written as placeholders,
+// which are replaced when the configuration is pushed.
+func convertGlobal(in *v1alpha1.GlobalConfig) *globalConfig {
+	if in == nil {
+		return nil
+	}
+	out := &globalConfig{
+		ResolveTimeout:   in.ResolveTimeout,
+		SMTPFrom:         in.SMTPFrom,
+		SMTPSmarthost:    in.SMTPSmarthost,
+		SMTPHello:        in.SMTPHello,
+		SMTPAuthUsername: in.SMTPAuthUsername,
+		SMTPAuthIdentity: in.SMTPAuthIdentity,
+		SMTPRequireTLS:   in.SMTPRequireTLS,
+		PagerdutyURL:     in.PagerdutyURL,
+		OpsgenieAPIURL:   in.OpsgenieAPIURL,
+		VictoropsAPIURL:  in.VictoropsAPIURL,
+		TelegramAPIURL:   in.TelegramAPIURL,
+	}
+	if in.SMTPAuthPasswordSecretRef != nil {
+		out.SMTPAuthPassword = placeholderOf(placeholderSMTPAuthPassword)
+	}
+	if in.SlackAPIURLSecretRef != nil {
+		out.SlackAPIURL = placeholderOf(placeholderSlackAPIURL)
+	}
+	if in.OpsgenieAPIKeySecretRef != nil {
+		out.OpsgenieAPIKey = placeholderOf(placeholderOpsgenieAPIKey)
+	}
+	if in.VictoropsAPIKeySecretRef != nil {
+		out.VictoropsAPIKey = placeholderOf(placeholderVictoropsAPIKey)
+	}
+	return out
+}
+
+// placeholderOf returns the placeholder of the value of name.
+func placeholderOf(name string) string {
+	return "${" + name + "}"
+}
+
+// convertRoute converts in and its child routes.
+func convertRoute(in v1alpha1.Route) *route {
+	out := convertRouteSettings(in.RouteSettings)
+	for _, c := range in.Routes {
+		out.Routes = append(out.Routes, convertChildRoute(c))
+	}
+	return out
+}
+
+func convertChildRoute(in v1alpha1.ChildRoute) *route {
+	out := convertRouteSettings(in.RouteSettings)
+	for _, c := range in.Routes {
+		out.Routes = append(out.Routes, convertGrandchildRoute(c))
+	}
+	return out
+}
+
+func convertGrandchildRoute(in v1alpha1.GrandchildRoute) *route {
+	out := convertRouteSettings(in.RouteSettings)
+	for _, c := range in.Routes {
+		out.Routes = append(out.Routes, convertRouteSettings(c))
+	}
+	return out
+}
+
+func convertRouteSettings(in v1alpha1.RouteSettings) *route {
+	return &route{
+		Receiver:            in.Receiver,
+		GroupBy:             in.GroupBy,
+		Continue:            in.Continue,
+		Matchers:            in.Matchers,
+		GroupWait:           in.GroupWait,
+		GroupInterval:       in.GroupInterval,
+		RepeatInterval:      in.RepeatInterval,
+		MuteTimeIntervals:   in.MuteTimeIntervals,
+		ActiveTimeIntervals: in.ActiveTimeIntervals,
+	}
+}
+
+// convertReceiver converts in. The fields of its notification integrations
+// are already named as in the config file, so they are written as their JSON
+// form is.
+func convertReceiver(in v1alpha1.Receiver, path string) (receiver, error) {
+	out := receiver{Name: in.Name}
+	for _, c := range []struct {
+		name string
+		in   interface{}
+		out  *[]interface{}
+	}{
+		{name: "emailConfigs", in: in.EmailConfigs, out: &out.EmailConfigs},
+		{name: "pagerdutyConfigs", in: in.PagerdutyConfigs, out: &out.PagerdutyConfigs},
+		{name: "slackConfigs", in: in.SlackConfigs, out: &out.SlackConfigs},
+		{name: "webhookConfigs", in: in.WebhookConfigs, out: &out.WebhookConfigs},
+		{name: "opsgenieConfigs", in: in.OpsgenieConfigs, out: &out.OpsgenieConfigs},
+		{name: "wechatConfigs", in: in.WechatConfigs, out: &out.WechatConfigs},
+		{name: "pushoverConfigs", in: in.PushoverConfigs, out: &out.PushoverConfigs},
+		{name: "victoropsConfigs", in: in.VictoropsConfigs, out: &out.VictoropsConfigs},
+		{name: "snsConfigs", in: in.SNSConfigs, out: &out.SNSConfigs},
+		{name: "telegramConfigs", in: in.TelegramConfigs, out: &out.TelegramConfigs},
+	} {
+		b, err := json.Marshal(c.in)
+		if err != nil {
+			return receiver{}, errors.Wrapf(err, "cannot convert %s.%s", path, c.name)
+		}
+		// JSON is YAML, which keeps integers as integers rather than turning
+		// them into floats, e.g. the chat_id of Telegram.
+		if err := yaml.Unmarshal(b, c.out); err != nil {
+			return receiver{}, errors.Wrapf(err, "cannot convert %s.%s", path, c.name)
+		}
+	}
+	return out, nil
+}
+
+func convertTimeInterval(in v1alpha1.TimeInterval) timeInterval {
+	out := timeInterval{Name: in.Name}
+	for _, s := range in.TimeIntervals {
+		spec := timeIntervalSpec{
+			Weekdays:    s.Weekdays,
+			DaysOfMonth: s.DaysOfMonth,
+			Months:      s.Months,
+			Years:       s.Years,
+			Location:    s.Location,
+		}
+		for _, t := range s.Times {
+			spec.Times = append(spec.Times, timeRange{StartTime: t.StartTime, EndTime: t.EndTime})
+		}
+		out.TimeIntervals = append(out.TimeIntervals, spec)
+	}
+	return out
+}
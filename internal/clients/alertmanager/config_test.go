@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+)
+
+func TestRenderConfig(t *testing.T) {
+	sendResolved := false
+	route := func(receiver string) v1alpha1.RouteSettings {
+		return v1alpha1.RouteSettings{Receiver: receiver}
+	}
+
+	cases := map[string]struct {
+		reason string
+		in     *v1alpha1.AlertmanagerConfig
+		want   string
+	}{
+		"NestedRoutes": {
+			reason: "Routes should be rendered at every level they can be nested at.",
+			in: &v1alpha1.AlertmanagerConfig{
+				Route: v1alpha1.Route{
+					RouteSettings: route("root"),
+					Routes: []v1alpha1.ChildRoute{{
+						RouteSettings: route("child"),
+						Routes: []v1alpha1.GrandchildRoute{{
+							RouteSettings: route("grandchild"),
+							Routes:        []v1alpha1.RouteSettings{route("leaf")},
+						}},
+					}},
+				},
+				Receivers: []v1alpha1.Receiver{{Name: "root"}},
+			},
+			want: `route:
+  receiver: root
+  routes:
+    - receiver: child
+      routes:
+        - receiver: grandchild
+          routes:
+            - receiver: leaf
+receivers:
+  - name: root
+`,
+		},
+		"TypedReceivers": {
+			reason: "Receivers should be rendered with the fields of the config file, keeping false flags and integers.",
+			in: &v1alpha1.AlertmanagerConfig{
+				Route: v1alpha1.Route{RouteSettings: route("team")},
+				Receivers: []v1alpha1.Receiver{{
+					Name: "team",
+					ReceiverConfigs: v1alpha1.ReceiverConfigs{
+						WebhookConfigs: []v1alpha1.WebhookConfig{{
+							SendResolved: &sendResolved,
+							URL:          "http://team.example.com/hook",
+							HTTPConfig:   &v1alpha1.HTTPConfig{BasicAuth: &v1alpha1.BasicAuth{Username: "team", Password: "${password}"}},
+						}},
+						TelegramConfigs: []v1alpha1.TelegramConfig{{BotToken: "${token}", ChatID: -1001234567890}},
+					},
+				}},
+			},
+			want: `route:
+  receiver: team
+receivers:
+  - name: team
+    webhook_configs:
+      - http_config:
+          basic_auth:
+            password: ${password}
+            username: team
+        send_resolved: false
+        url: http://team.example.com/hook
+    telegram_configs:
+      - bot_token: ${token}
+        chat_id: -1001234567890
+`,
+		},
+		"GlobalSecretRefs": {
+			reason: "The secret references of the global parameters should be rendered as placeholders.",
+			in: &v1alpha1.AlertmanagerConfig{
+				Global: &v1alpha1.GlobalConfig{
+					SMTPFrom:                "alertmanager@example.org",
+					OpsgenieAPIKeySecretRef: &xpv1.SecretKeySelector{Key: "opsgenie"},
+				},
+				Route:     v1alpha1.Route{RouteSettings: route("team")},
+				Receivers: []v1alpha1.Receiver{{Name: "team"}},
+			},
+			want: `global:
+  smtp_from: alertmanager@example.org
+  opsgenie_api_key: ${global.opsgenieApiKey}
+route:
+  receiver: team
+receivers:
+  - name: team
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := RenderConfig(tc.in)
+			if err != nil {
+				t.Fatalf("\n%s\nRenderConfig(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nRenderConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package alertmanager
+
+import (
+	"context"
+	"time"
+)
+
+// States of a silence.
+const (
+	SilenceStatePending = "pending"
+	SilenceStateActive  = "active"
+	SilenceStateExpired = "expired"
+)
+
+type SilenceClient interface {
+	GetSilence(ctx context.Context, id string) (*Silence, error)
+	ListSilences(ctx context.Context) ([]Silence, error)
+	CreateSilence(ctx context.Context, s Silence) (string, error)
+	DeleteSilence(ctx context.Context, id string) error
+}
+
+// Silence is a silence of the Alertmanager v2 API. A silence with an ID
+// replaces the existing silence.
+type Silence struct {
+	ID        string         `json:"id,omitempty"`
+	Matchers  []Matcher      `json:"matchers"`
+	StartsAt  time.Time      `json:"startsAt"`
+	EndsAt    time.Time      `json:"endsAt"`
+	CreatedBy string         `json:"createdBy"`
+	Comment   string         `json:"comment"`
+	Status    *SilenceStatus `json:"status,omitempty"`
+	UpdatedAt *time.Time     `json:"updatedAt,omitempty"`
+}
+
+// Matcher matches the value of a label of an alert.
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// SilenceStatus is the state of a silence.
+type SilenceStatus struct {
+	State string `json:"state"`
+}
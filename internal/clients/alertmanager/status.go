@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"context"
+)
+
+// States of an alert.
+const (
+	AlertStateActive     = "active"
+	AlertStateSuppressed = "suppressed"
+)
+
+// StatusClient reads the runtime state of the Alertmanager of a tenant.
+type StatusClient interface {
+	GetAlertmanagerStatus(ctx context.Context) (*Status, error)
+	ListReceivers(ctx context.Context) ([]ReceiverName, error)
+	ListAlerts(ctx context.Context, limit int) ([]Alert, error)
+}
+
+// Status is the status of the Alertmanager of a tenant.
+type Status struct {
+	Cluster ClusterStatus `json:"cluster"`
+	Config  ConfigStatus  `json:"config"`
+}
+
+// ClusterStatus is the state of the cluster of an Alertmanager.
+type ClusterStatus struct {
+	Name   string       `json:"name,omitempty"`
+	Status string       `json:"status"`
+	Peers  []PeerStatus `json:"peers,omitempty"`
+}
+
+// PeerStatus is a peer of the cluster of an Alertmanager.
+type PeerStatus struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// ConfigStatus is the configuration an Alertmanager runs. Its secrets are
+// redacted.
+type ConfigStatus struct {
+	Original string `json:"original"`
+}
+
+// ReceiverName is a receiver of the configuration an Alertmanager runs.
+type ReceiverName struct {
+	Name string `json:"name"`
+}
+
+// Alert is an alert of an Alertmanager. Only its state is decoded.
+type Alert struct {
+	Status AlertStatus `json:"status"`
+}
+
+// AlertStatus is the state of an alert.
+type AlertStatus struct {
+	State string `json:"state"`
+}
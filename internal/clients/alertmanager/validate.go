@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	htmltemplate "html/template"
+	"sort"
+	texttemplate "text/template"
+
+	"github.com/pkg/errors"
+	amconfig "github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/template"
+)
+
+// ValidateConfig parses an alert manager config file with the config loader
+// of the Alertmanager. Its errors name the offending key or line.
+func ValidateConfig(cfg string) error {
+	_, err := amconfig.Load(cfg)
+	return err
+}
+
+// ValidateTemplates compiles every template file with the text and HTML
+// template engines of the Alertmanager, which has the same functions. It
+// returns the error of the first file in the order of their names, which
+// names the file and the offending line.
+func ValidateTemplates(templates map[string]string) error {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, err := texttemplate.New(name).Option("missingkey=zero").Funcs(texttemplate.FuncMap(template.DefaultFuncs)).Parse(templates[name]); err != nil {
+			return errors.Wrapf(err, "template_files[%s]", name)
+		}
+		if _, err := htmltemplate.New(name).Option("missingkey=zero").Funcs(htmltemplate.FuncMap(template.DefaultFuncs)).Parse(templates[name]); err != nil {
+			return errors.Wrapf(err, "template_files[%s]", name)
+		}
+	}
+	return nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"time"
 
 	cortexClient "github.com/cortexproject/cortex-tools/pkg/client"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -22,18 +25,64 @@ const (
 	errGetCredentials            = "cannot get credentials"
 )
 
+// requestTimeout bounds every request to Cortex, so that an unresponsive
+// endpoint does not block a reconcile.
+const requestTimeout = 30 * time.Second
+
 type Config struct {
 	cortexClientConfig cortexClient.Config
+	backend            string
+}
+
+// TenantID returns the tenant requests are sent for.
+func (c Config) TenantID() string {
+	return c.cortexClientConfig.ID
+}
+
+// Backend returns the kind of server requests are sent to.
+func (c Config) Backend() string {
+	return c.backend
+}
+
+// Client is a Cortex API client. It embeds the cortex-tools client and adds
+// the endpoints and payloads that client does not support.
+type Client struct {
+	*cortexClient.CortexClient
+
+	cfg      cortexClient.Config
+	backend  string
+	endpoint *url.URL
+	client   http.Client
 }
 
 // NewClient creates new Cortex Client with provided Cortex Configurations.
-func NewClient(config Config) *cortexClient.CortexClient {
+func NewClient(config Config) *Client {
 	c, err := cortexClient.New(config.cortexClientConfig)
 
 	if err != nil {
 		fmt.Printf("Could not initialize cortex client: %v", err)
 	}
-	return c
+
+	// cortexClient.New already reported an invalid address.
+	endpoint, _ := url.Parse(config.cortexClientConfig.Address)
+
+	// Requests the cortex-tools client does not support use its transport,
+	// which carries the TLS configuration.
+	transport := http.DefaultTransport
+	if c != nil {
+		c.Client.Timeout = requestTimeout
+		if c.Client.Transport != nil {
+			transport = c.Client.Transport
+		}
+	}
+
+	return &Client{
+		CortexClient: c,
+		cfg:          config.cortexClientConfig,
+		backend:      config.backend,
+		endpoint:     endpoint,
+		client:       http.Client{Transport: transport, Timeout: requestTimeout},
+	}
 }
 
 // GetConfig constructs a Config that can be used to authenticate to Cortex
@@ -80,5 +129,5 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 		cfg.AuthToken = m[pc.Spec.SecretKeys.AuthToken]
 	}
 
-	return &Config{cortexClientConfig: cfg}, nil
+	return &Config{cortexClientConfig: cfg, backend: pc.Spec.Backend}, nil
 }
@@ -0,0 +1,145 @@
+package clients
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	cortexClient "github.com/cortexproject/cortex-tools/pkg/client"
+	"github.com/pkg/errors"
+)
+
+const (
+	errClientNotInitialized = "cortex client is not initialized"
+	errBasicAuthAndToken    = "atmost one of basic auth or auth token should be configured"
+)
+
+// doRequest sends a request to the Cortex API the same way the cortex-tools
+// client does, for the endpoints that client does not cover.
+func (c *Client) doRequest(ctx context.Context, method, path string, query url.Values, payload []byte) (*http.Response, error) {
+	req, err := c.newRequest(ctx, method, path, query, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(req)
+}
+
+// doJSONRequest sends in as JSON, unless it is nil, and decodes the response
+// into out, unless it is nil.
+func (c *Client) doJSONRequest(ctx context.Context, method, path string, in, out interface{}) error {
+	var payload []byte
+	if in != nil {
+		var err error
+		if payload, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	req, err := c.newRequest(ctx, method, path, nil, payload)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() //nolint:errcheck // only read from
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// newRequest creates an authenticated request for the tenant.
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, payload []byte) (*http.Request, error) {
+	if c.endpoint == nil {
+		return nil, errors.New(errClientNotInitialized)
+	}
+
+	// path may contain escaped segments, e.g. a namespace with a slash, which
+	// have to be kept escaped when joined with the path of the endpoint.
+	p, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := *c.endpoint
+	if p.RawPath != "" || u.RawPath != "" {
+		u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + p.EscapedPath()
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + p.Path
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	if (c.cfg.User != "" || c.cfg.Key != "") && c.cfg.AuthToken != "" {
+		return nil, errors.New(errBasicAuthAndToken)
+	}
+
+	if c.cfg.User != "" {
+		req.SetBasicAuth(c.cfg.User, c.cfg.Key)
+	} else if c.cfg.Key != "" {
+		req.SetBasicAuth(c.cfg.ID, c.cfg.Key)
+	}
+
+	if c.cfg.AuthToken != "" {
+		req.Header.Add("Authorization", "Bearer "+c.cfg.AuthToken)
+	}
+
+	req.Header.Add("X-Scope-OrgID", c.cfg.ID)
+
+	return req, nil
+}
+
+// do sends a request and turns a non 2xx response into an error.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close() //nolint:errcheck // the response error is more relevant
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// checkResponse turns a non 2xx response into an error. A 404 is reported as
+// cortexClient.ErrResourceNotFound.
+func checkResponse(r *http.Response) error {
+	if 200 <= r.StatusCode && r.StatusCode <= 299 {
+		return nil
+	}
+
+	if r.StatusCode == http.StatusNotFound {
+		return cortexClient.ErrResourceNotFound
+	}
+
+	var msg string
+	scanner := bufio.NewScanner(io.LimitReader(r.Body, 512))
+	if scanner.Scan() {
+		msg = scanner.Text()
+	}
+
+	if msg == "" {
+		return fmt.Errorf("server returned HTTP status %s", r.Status)
+	}
+	return fmt.Errorf("server returned HTTP status %s: %s", r.Status, msg)
+}
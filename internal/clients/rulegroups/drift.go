@@ -0,0 +1,174 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroups
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/common/model"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+)
+
+// ruleGroupState is the comparable form of a rule group. The yaml tags name
+// the fields of a drift after the fields of the spec.
+type ruleGroupState struct {
+	Interval                      model.Duration     `yaml:"interval"`
+	Limit                         int                `yaml:"limit"`
+	SourceTenants                 []string           `yaml:"sourceTenants"`
+	EvaluationDelay               model.Duration     `yaml:"evaluationDelay"`
+	QueryOffset                   model.Duration     `yaml:"queryOffset"`
+	AlignEvaluationTimeOnInterval bool               `yaml:"alignEvaluationTimeOnInterval"`
+	RemoteWrite                   []remoteWriteState `yaml:"remoteWrite"`
+	Rules                         []ruleState        `yaml:"rules"`
+}
+
+// remoteWriteState is the comparable form of a remote write config. It is
+// converted from rwrulefmt.RemoteWriteConfig, so it compares every field of
+// it.
+type remoteWriteState struct {
+	URL string `yaml:"url"`
+}
+
+// ruleState is the comparable form of a rule. Unlike rulefmt.RuleNode it does
+// not carry the position of the YAML nodes it was parsed from.
+type ruleState struct {
+	Record        string            `yaml:"record"`
+	Alert         string            `yaml:"alert"`
+	Expr          string            `yaml:"expr"`
+	For           model.Duration    `yaml:"for"`
+	KeepFiringFor model.Duration    `yaml:"keepFiringFor"`
+	Labels        map[string]string `yaml:"labels"`
+	Annotations   map[string]string `yaml:"annotations"`
+}
+
+// String identifies the rule when it is added to or removed from a group.
+func (s ruleState) String() string {
+	if s.Record != "" {
+		return "record: " + s.Record
+	}
+	return "alert: " + s.Alert
+}
+
+func newRuleGroupState(rg *RuleGroup) ruleGroupState {
+	s := ruleGroupState{
+		Interval:                      rg.Interval,
+		Limit:                         rg.Limit,
+		SourceTenants:                 rg.SourceTenants,
+		EvaluationDelay:               rg.EvaluationDelay,
+		QueryOffset:                   rg.QueryOffset,
+		AlignEvaluationTimeOnInterval: rg.AlignEvaluationTimeOnInterval,
+		Rules:                         make([]ruleState, len(rg.Rules)),
+	}
+	for _, rw := range rg.RWConfigs {
+		s.RemoteWrite = append(s.RemoteWrite, remoteWriteState(rw))
+	}
+	for i, rule := range rg.Rules {
+		s.Rules[i] = ruleState{
+			Record:        rule.Record.Value,
+			Alert:         rule.Alert.Value,
+			Expr:          rule.Expr.Value,
+			For:           rule.For,
+			KeepFiringFor: rule.KeepFiringFor,
+			Labels:        rule.Labels,
+			Annotations:   rule.Annotations,
+		}
+	}
+	return s
+}
+
+// Drift returns every field that differs between the desired and the
+// observed rule group. The name of the group is not compared as the observed
+// group is looked up by it.
+func Drift(desired, observed *RuleGroup) []v1alpha1.RuleGroupDrift {
+	r := &driftReporter{}
+	cmp.Equal(newRuleGroupState(desired), newRuleGroupState(observed), cmpopts.EquateEmpty(), cmp.Reporter(r))
+	return r.drift
+}
+
+// Diff returns a human readable report of the differences between the
+// desired and the observed rule group.
+func Diff(desired, observed *RuleGroup) string {
+	return cmp.Diff(newRuleGroupState(desired), newRuleGroupState(observed), cmpopts.EquateEmpty())
+}
+
+// driftReporter is a cmp.Reporter that records every unequal leaf of a
+// comparison as a RuleGroupDrift.
+type driftReporter struct {
+	path  cmp.Path
+	drift []v1alpha1.RuleGroupDrift
+}
+
+func (r *driftReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *driftReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	vx, vy := r.path.Last().Values()
+	r.drift = append(r.drift, v1alpha1.RuleGroupDrift{
+		Field:    fieldPath(r.path),
+		Desired:  formatValue(vx),
+		Observed: formatValue(vy),
+	})
+}
+
+func (r *driftReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+// fieldPath formats a cmp.Path using the yaml names of the visited fields,
+// e.g. rules[1].labels[severity].
+func fieldPath(p cmp.Path) string {
+	b := strings.Builder{}
+	for i, ps := range p {
+		switch s := ps.(type) {
+		case cmp.StructField:
+			name := s.Name()
+			if f, ok := p.Index(i - 1).Type().FieldByName(name); ok {
+				name = strings.Split(f.Tag.Get("yaml"), ",")[0]
+			}
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(name)
+		case cmp.SliceIndex:
+			// An added or removed element only has an index on one side.
+			ix, iy := s.SplitKeys()
+			if ix < 0 {
+				ix = iy
+			}
+			fmt.Fprintf(&b, "[%d]", ix)
+		case cmp.MapIndex:
+			fmt.Fprintf(&b, "[%v]", s.Key())
+		}
+	}
+	return b.String()
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
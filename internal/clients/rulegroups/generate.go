@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rulegroups
+
+import (
+	"github.com/cortexproject/cortex-tools/pkg/rules/rwrulefmt"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v3"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+)
+
+// NewRuleGroup generates a Cortex RuleGroup from the settings of a Kubernetes
+// rule group and its resolved rules.
+func NewRuleGroup(name string, p v1alpha1.RuleGroupSettings, rules []v1alpha1.RuleNode) (*RuleGroup, error) {
+	rns := []RuleNode{}
+
+	// iterate through group rules
+	for _, rule := range rules {
+		rn, err := generateRuleNode(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		rns = append(rns, *rn)
+	}
+
+	var interval model.Duration
+	var err error
+
+	if p.Interval != nil {
+		interval, err = model.ParseDuration(*p.Interval)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rg := &RuleGroup{
+		Name:          name,
+		Interval:      interval,
+		Rules:         rns,
+		SourceTenants: p.SourceTenants,
+	}
+	if p.Limit != nil {
+		rg.Limit = *p.Limit
+	}
+	if p.EvaluationDelay != nil {
+		if rg.EvaluationDelay, err = model.ParseDuration(*p.EvaluationDelay); err != nil {
+			return nil, err
+		}
+	}
+	if p.QueryOffset != nil {
+		if rg.QueryOffset, err = model.ParseDuration(*p.QueryOffset); err != nil {
+			return nil, err
+		}
+	}
+	if p.AlignEvaluationTimeOnInterval != nil {
+		rg.AlignEvaluationTimeOnInterval = *p.AlignEvaluationTimeOnInterval
+	}
+	// The remote write configs are converted rather than copied field by
+	// field, so a field the ruler does not support fails to compile instead
+	// of being dropped silently.
+	for _, rw := range p.RemoteWrite {
+		rg.RWConfigs = append(rg.RWConfigs, rwrulefmt.RemoteWriteConfig(rw))
+	}
+
+	return rg, nil
+}
+
+// generates a Cortex RuleNode from a Kubernetes RuleNode
+func generateRuleNode(specRuleNode v1alpha1.RuleNode) (*RuleNode, error) {
+	rn := RuleNode{}
+
+	if specRuleNode.Record != nil {
+		yn := yaml.Node{}
+		err := yaml.Unmarshal([]byte(*specRuleNode.Record), &yn)
+		if err != nil {
+			return nil, err
+		}
+		// we are interested in the ScalarNode
+		rn.Record = *yn.Content[0]
+	}
+	if specRuleNode.Alert != nil {
+		yn := yaml.Node{}
+		err := yaml.Unmarshal([]byte(*specRuleNode.Alert), &yn)
+		if err != nil {
+			return nil, err
+		}
+		rn.Alert = *yn.Content[0]
+	}
+	yn := yaml.Node{}
+	err := yaml.Unmarshal([]byte(specRuleNode.Expr), &yn)
+	if err != nil {
+		return nil, err
+	}
+	rn.Expr = *yn.Content[0]
+	if specRuleNode.For != nil {
+		rn.For, err = model.ParseDuration(*specRuleNode.For)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if specRuleNode.KeepFiringFor != nil {
+		rn.KeepFiringFor, err = model.ParseDuration(*specRuleNode.KeepFiringFor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(specRuleNode.Labels) != 0 {
+		rn.Labels = specRuleNode.Labels
+	}
+	if len(specRuleNode.Annotations) != 0 {
+		rn.Annotations = specRuleNode.Annotations
+	}
+
+	return &rn, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroups
+
+import (
+	"testing"
+
+	"github.com/cortexproject/cortex-tools/pkg/rules/rwrulefmt"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+)
+
+func TestNewRuleGroup(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.RuleGroupSettings
+		want   *RuleGroup
+	}{
+		"Federation": {
+			reason: "The source tenants and every remote write target should be pushed.",
+			p: v1alpha1.RuleGroupSettings{
+				SourceTenants: []string{"team-a", "team-b"},
+				RemoteWrite: []v1alpha1.RemoteWriteConfig{
+					{URL: "http://a.example.org/push"},
+					{URL: "http://b.example.org/push"},
+				},
+			},
+			want: &RuleGroup{
+				Name:          "slo",
+				Rules:         []RuleNode{},
+				SourceTenants: []string{"team-a", "team-b"},
+				RWConfigs: []rwrulefmt.RemoteWriteConfig{
+					{URL: "http://a.example.org/push"},
+					{URL: "http://b.example.org/push"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewRuleGroup("slo", tc.p, nil)
+			if err != nil {
+				t.Fatalf("\n%s\nNewRuleGroup(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewRuleGroup(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
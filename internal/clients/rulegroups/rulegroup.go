@@ -17,12 +17,87 @@ package rulegroups
 
 import (
 	"context"
+	"time"
 
 	"github.com/cortexproject/cortex-tools/pkg/rules/rwrulefmt"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/rulefmt"
 )
 
 type RuleGroupClient interface {
-	GetRuleGroup(ctx context.Context, namespace string, groupName string) (*rwrulefmt.RuleGroup, error)
-	CreateRuleGroup(ctx context.Context, namespace string, rg rwrulefmt.RuleGroup) error
+	GetRuleGroup(ctx context.Context, namespace string, groupName string) (*RuleGroup, error)
+	CreateRuleGroup(ctx context.Context, namespace string, rg RuleGroup) error
 	DeleteRuleGroup(ctx context.Context, namespace string, groupName string) error
+	ListRuleGroups(ctx context.Context, namespace string) ([]RuleGroup, error)
+	GetRuleGroupHealth(ctx context.Context, namespace string, groupName string) (*RulesResponse, error)
+}
+
+// RuleGroup is a list of sequentially evaluated recording and alerting rules
+// as accepted by the ruler API. It mirrors rwrulefmt.RuleGroup but supports
+// fields the vendored Prometheus rulefmt does not know about.
+type RuleGroup struct {
+	Name     string         `yaml:"name"`
+	Interval model.Duration `yaml:"interval,omitempty"`
+	Limit    int            `yaml:"limit,omitempty"`
+	Rules    []RuleNode     `yaml:"rules"`
+
+	// RWConfigs is used by the remote write forwarding ruler.
+	RWConfigs []rwrulefmt.RemoteWriteConfig `yaml:"remote_write,omitempty"`
+
+	// SourceTenants is used by rulers with tenant federation enabled.
+	SourceTenants []string `yaml:"source_tenants,omitempty"`
+
+	// The following fields are only supported by Mimir.
+	EvaluationDelay               model.Duration `yaml:"evaluation_delay,omitempty"`
+	QueryOffset                   model.Duration `yaml:"query_offset,omitempty"`
+	AlignEvaluationTimeOnInterval bool           `yaml:"align_evaluation_time_on_interval,omitempty"`
+}
+
+// RuleNode adds keep_firing_for to rulefmt.RuleNode.
+type RuleNode struct {
+	rulefmt.RuleNode `yaml:",inline"`
+	KeepFiringFor    model.Duration `yaml:"keep_firing_for,omitempty"`
+}
+
+// RulesResponse is the response of the Prometheus compatible rules API of
+// the ruler.
+type RulesResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Groups []RuleGroupHealth `json:"groups"`
+	} `json:"data"`
+	ErrorType string `json:"errorType,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RuleGroupHealth is the evaluation state of a rule group as reported by the
+// rules API. File is the namespace of the group.
+type RuleGroupHealth struct {
+	Name           string       `json:"name"`
+	File           string       `json:"file"`
+	Rules          []RuleHealth `json:"rules"`
+	LastEvaluation time.Time    `json:"lastEvaluation"`
+	EvaluationTime float64      `json:"evaluationTime"`
+}
+
+// RuleHealth is the evaluation state of a single rule. EvaluationTime is in
+// seconds. Alerts are only reported for alerting rules.
+type RuleHealth struct {
+	Name           string    `json:"name"`
+	Query          string    `json:"query"`
+	Type           string    `json:"type"`
+	Health         string    `json:"health"`
+	LastError      string    `json:"lastError,omitempty"`
+	LastEvaluation time.Time `json:"lastEvaluation"`
+	EvaluationTime float64   `json:"evaluationTime"`
+	Alerts         []Alert   `json:"alerts,omitempty"`
+}
+
+// Alert is a pending or firing instance of an alerting rule.
+type Alert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	State       string            `json:"state"`
+	ActiveAt    *time.Time        `json:"activeAt,omitempty"`
+	Value       string            `json:"value"`
 }
@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroups
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/template"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
+)
+
+const errFmtMimirOnly = "%s is only supported by the mimir backend, not by %s"
+
+// templateDefs are the variables the ruler defines for alert templates.
+var templateDefs = []string{
+	"{{$labels := .Labels}}",
+	"{{$externalLabels := .ExternalLabels}}",
+	"{{$externalURL := .ExternalURL}}",
+	"{{$value := .Value}}",
+}
+
+// An ExprValidator checks the syntax of the expression of a rule.
+type ExprValidator func(expr string) error
+
+// ValidatePromQL parses expr with the PromQL parser.
+func ValidatePromQL(expr string) error {
+	_, err := parser.ParseExpr(expr)
+	return err
+}
+
+// ExprValidatorFor returns the ExprValidator whose failures block pushing
+// the rules of a backend, or nil if expressions are left to the ruler.
+// Backends other than loki use PromQL. LogQL expressions are not validated,
+// Loki rejects invalid ones when they are pushed.
+func ExprValidatorFor(backend string) ExprValidator {
+	if backend == apisv1alpha1.BackendLoki {
+		return nil
+	}
+	return ValidatePromQL
+}
+
+// ValidateBackendFields rejects the fields of a rule group only Mimir
+// supports unless the backend is mimir. Other backends would silently drop
+// them.
+func ValidateBackendFields(backend string, p v1alpha1.RuleGroupSettings) error {
+	if backend == apisv1alpha1.BackendMimir {
+		return nil
+	}
+	if backend == "" {
+		backend = apisv1alpha1.BackendCortex
+	}
+
+	var errs []error
+	if len(p.SourceTenants) != 0 {
+		errs = append(errs, errors.Errorf(errFmtMimirOnly, "sourceTenants", backend))
+	}
+	if p.EvaluationDelay != nil {
+		errs = append(errs, errors.Errorf(errFmtMimirOnly, "evaluationDelay", backend))
+	}
+	if p.QueryOffset != nil {
+		errs = append(errs, errors.Errorf(errFmtMimirOnly, "queryOffset", backend))
+	}
+	if p.AlignEvaluationTimeOnInterval != nil {
+		errs = append(errs, errors.Errorf(errFmtMimirOnly, "alignEvaluationTimeOnInterval", backend))
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// ValidateRules parses the expression of every rule with validateExpr, unless
+// it is nil, and the labels and annotations of every alerting rule with the
+// alert template expander. The returned error names the rule index, the field
+// and the position of every failure.
+func ValidateRules(rules []v1alpha1.RuleNode, validateExpr ExprValidator) error {
+	var errs []error
+	for i, rule := range rules {
+		if validateExpr != nil {
+			if err := validateExpr(rule.Expr); err != nil {
+				errs = append(errs, errors.Wrapf(err, "rules[%d].expr", i))
+			}
+		}
+
+		// Labels and annotations are only expanded for alerting rules.
+		if rule.Alert == nil {
+			continue
+		}
+		for _, k := range sortedKeys(rule.Labels) {
+			if err := parseTemplate(*rule.Alert, rule.Labels[k]); err != nil {
+				errs = append(errs, errors.Wrapf(err, "rules[%d].labels[%s]", i, k))
+			}
+		}
+		for _, k := range sortedKeys(rule.Annotations) {
+			if err := parseTemplate(*rule.Alert, rule.Annotations[k]); err != nil {
+				errs = append(errs, errors.Wrapf(err, "rules[%d].annotations[%s]", i, k))
+			}
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// parseTemplate parses text the same way the ruler does when it expands the
+// labels and annotations of an alert.
+func parseTemplate(alert, text string) error {
+	tmpl := template.NewTemplateExpander(
+		context.Background(),
+		strings.Join(append(templateDefs, text), ""),
+		fmt.Sprintf("__alert_%s", alert),
+		template.AlertTemplateData(map[string]string{}, map[string]string{}, "", 0),
+		model.TimeFromUnixNano(time.Now().UnixNano()),
+		nil,
+		nil,
+		nil,
+	)
+	return tmpl.ParseTest()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
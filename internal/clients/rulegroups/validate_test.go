@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroups
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
+)
+
+func TestValidateRules(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		rules  []v1alpha1.RuleNode
+		want   string
+	}{
+		"Valid": {
+			reason: "Valid expressions and templates should pass.",
+			rules: []v1alpha1.RuleNode{
+				{Record: strPtr("job:up:sum"), Expr: "sum by (job) (up)"},
+				{Alert: strPtr("Down"), Expr: "up == 0", Annotations: map[string]string{"summary": "{{ $labels.instance }} is down"}},
+			},
+		},
+		"InvalidExpr": {
+			reason: "An invalid expression should name the rule index, field and position.",
+			rules: []v1alpha1.RuleNode{
+				{Record: strPtr("job:up:sum"), Expr: "sum(up)"},
+				{Record: strPtr("job:down:sum"), Expr: "sum(up"},
+			},
+			want: "rules[1].expr: 1:7: parse error: unclosed left parenthesis",
+		},
+		"InvalidTemplate": {
+			reason: "An invalid annotation template should name the rule index, field and position.",
+			rules: []v1alpha1.RuleNode{
+				{Alert: strPtr("Down"), Expr: "up == 0", Annotations: map[string]string{"summary": "{{ $labels.instance }"}},
+			},
+			want: `rules[0].annotations[summary]: template: __alert_Down:1: unexpected "}" in operand`,
+		},
+		"RecordingRuleLabelsNotTemplated": {
+			reason: "Labels of recording rules are not templates.",
+			rules: []v1alpha1.RuleNode{
+				{Record: strPtr("job:up:sum"), Expr: "sum(up)", Labels: map[string]string{"note": "{{"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ""
+			if err := ValidateRules(tc.rules, ValidatePromQL); err != nil {
+				got = err.Error()
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nValidateRules(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateBackendFields(t *testing.T) {
+	mimirOnly := v1alpha1.RuleGroupSettings{
+		SourceTenants:                 []string{"team-a"},
+		QueryOffset:                   strPtr("1m"),
+		AlignEvaluationTimeOnInterval: boolPtr(true),
+	}
+
+	cases := map[string]struct {
+		reason  string
+		backend string
+		p       v1alpha1.RuleGroupSettings
+		want    string
+	}{
+		"Mimir": {
+			reason:  "The mimir backend should accept its fields.",
+			backend: apisv1alpha1.BackendMimir,
+			p:       mimirOnly,
+		},
+		"Cortex": {
+			reason:  "The cortex backend should reject every Mimir only field.",
+			backend: apisv1alpha1.BackendCortex,
+			p:       mimirOnly,
+			want: "[sourceTenants is only supported by the mimir backend, not by cortex, " +
+				"queryOffset is only supported by the mimir backend, not by cortex, " +
+				"alignEvaluationTimeOnInterval is only supported by the mimir backend, not by cortex]",
+		},
+		"Default": {
+			reason: "An unset backend is cortex.",
+			p:      v1alpha1.RuleGroupSettings{EvaluationDelay: strPtr("1m")},
+			want:   "evaluationDelay is only supported by the mimir backend, not by cortex",
+		},
+		"CommonFields": {
+			reason:  "Fields all backends support should be accepted.",
+			backend: apisv1alpha1.BackendLoki,
+			p:       v1alpha1.RuleGroupSettings{Interval: strPtr("1m"), Limit: func() *int { l := 10; return &l }()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ""
+			if err := ValidateBackendFields(tc.backend, tc.p); err != nil {
+				got = err.Error()
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nValidateBackendFields(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func boolPtr(b bool) *bool { return &b }
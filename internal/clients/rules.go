@@ -0,0 +1,144 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	cortexClient "github.com/cortexproject/cortex-tools/pkg/client"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/swisscom/provider-cortex/apis/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/rulegroups"
+)
+
+const (
+	rulerAPIPath        = "/api/v1/rules"
+	mimirRulerAPIPath   = "/prometheus/config/v1/rules"
+	lokiAPIPrefix       = "/loki"
+	prometheusAPIPrefix = "/prometheus"
+
+	errUnmarshalRuleGroup = "unable to unmarshal rule group from response"
+	errUnmarshalRules     = "unable to unmarshal rules from response"
+)
+
+// rulerAPIPath returns the path of the ruler configuration API of the
+// backend, which differs per backend: Cortex serves it under /api/v1/rules,
+// Loki under /loki/api/v1/rules and Mimir under /prometheus/config/v1/rules.
+func (c *Client) rulerAPIPath() string {
+	switch c.backend {
+	case v1alpha1.BackendLoki:
+		return lokiAPIPrefix + rulerAPIPath
+	case v1alpha1.BackendMimir:
+		return mimirRulerAPIPath
+	default:
+		return rulerAPIPath
+	}
+}
+
+// GetRuleGroup retrieves a rule group.
+func (c *Client) GetRuleGroup(ctx context.Context, namespace, groupName string) (*rulegroups.RuleGroup, error) {
+	res, err := c.doRequest(ctx, http.MethodGet, c.rulerAPIPath()+"/"+url.PathEscape(namespace)+"/"+url.PathEscape(groupName), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() //nolint:errcheck // only read from
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	rg := rulegroups.RuleGroup{}
+	if err := yaml.Unmarshal(body, &rg); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalRuleGroup)
+	}
+
+	return &rg, nil
+}
+
+// CreateRuleGroup creates or replaces a rule group.
+func (c *Client) CreateRuleGroup(ctx context.Context, namespace string, rg rulegroups.RuleGroup) error {
+	payload, err := yaml.Marshal(&rg)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.doRequest(ctx, http.MethodPost, c.rulerAPIPath()+"/"+url.PathEscape(namespace), nil, payload)
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+// DeleteRuleGroup deletes a rule group.
+func (c *Client) DeleteRuleGroup(ctx context.Context, namespace, groupName string) error {
+	res, err := c.doRequest(ctx, http.MethodDelete, c.rulerAPIPath()+"/"+url.PathEscape(namespace)+"/"+url.PathEscape(groupName), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+// ListRuleGroups retrieves all rule groups of a namespace. A namespace
+// without rule groups does not exist for the ruler, so no rule groups are
+// returned for it rather than an error.
+func (c *Client) ListRuleGroups(ctx context.Context, namespace string) ([]rulegroups.RuleGroup, error) {
+	res, err := c.doRequest(ctx, http.MethodGet, c.rulerAPIPath()+"/"+url.PathEscape(namespace), nil, nil)
+	if errors.Is(err, cortexClient.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() //nolint:errcheck // only read from
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string][]rulegroups.RuleGroup{}
+	if err := yaml.Unmarshal(body, &namespaces); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalRuleGroup)
+	}
+
+	return namespaces[namespace], nil
+}
+
+// GetRuleGroupHealth retrieves the evaluation state of a rule group from the
+// Prometheus compatible rules API, which unlike the ruler configuration API
+// all backends serve under the same path. Only the requested group is kept in
+// the response.
+func (c *Client) GetRuleGroupHealth(ctx context.Context, namespace, groupName string) (*rulegroups.RulesResponse, error) {
+	q := url.Values{}
+	q.Add("file[]", namespace)
+	q.Add("rule_group[]", groupName)
+
+	res, err := c.doRequest(ctx, http.MethodGet, prometheusAPIPrefix+"/api/v1/rules", q, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() //nolint:errcheck // only read from
+
+	rr := &rulegroups.RulesResponse{}
+	if err := json.NewDecoder(res.Body).Decode(rr); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalRules)
+	}
+
+	// Older rulers ignore the filters.
+	groups := rr.Data.Groups[:0]
+	for _, g := range rr.Data.Groups {
+		if g.File == namespace && g.Name == groupName {
+			groups = append(groups, g)
+		}
+	}
+	rr.Data.Groups = groups
+
+	return rr, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cortexClient "github.com/cortexproject/cortex-tools/pkg/client"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/swisscom/provider-cortex/apis/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/rulegroups"
+)
+
+func TestRuleGroupPaths(t *testing.T) {
+	type want struct {
+		create string
+		delete string
+	}
+
+	cases := map[string]struct {
+		reason  string
+		backend string
+		want    want
+	}{
+		"Cortex": {
+			reason:  "Rule groups of a cortex backend should be sent to the Cortex ruler API.",
+			backend: v1alpha1.BackendCortex,
+			want:    want{create: "/api/v1/rules/team%2Fa", delete: "/api/v1/rules/team%2Fa/example"},
+		},
+		"Default": {
+			reason: "An unset backend is cortex.",
+			want:   want{create: "/api/v1/rules/team%2Fa", delete: "/api/v1/rules/team%2Fa/example"},
+		},
+		"Mimir": {
+			reason:  "Rule groups of a mimir backend should be sent to the Mimir ruler API.",
+			backend: v1alpha1.BackendMimir,
+			want:    want{create: "/prometheus/config/v1/rules/team%2Fa", delete: "/prometheus/config/v1/rules/team%2Fa/example"},
+		},
+		"Loki": {
+			reason:  "Rule groups of a loki backend should be sent to the Loki ruler API.",
+			backend: v1alpha1.BackendLoki,
+			want:    want{create: "/loki/api/v1/rules/team%2Fa", delete: "/loki/api/v1/rules/team%2Fa/example"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := want{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodPost:
+					got.create = r.URL.EscapedPath()
+				case http.MethodDelete:
+					got.delete = r.URL.EscapedPath()
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer srv.Close()
+
+			c := NewClient(Config{cortexClientConfig: cortexClient.Config{Address: srv.URL, ID: "tenant"}, backend: tc.backend})
+			if err := c.CreateRuleGroup(context.Background(), "team/a", rulegroups.RuleGroup{}); err != nil {
+				t.Fatalf("\n%s\nc.CreateRuleGroup(...): %v\n", tc.reason, err)
+			}
+			if err := c.DeleteRuleGroup(context.Background(), "team/a", "example"); err != nil {
+				t.Fatalf("\n%s\nc.DeleteRuleGroup(...): %v\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nrule group paths: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
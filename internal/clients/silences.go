@@ -0,0 +1,47 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
+)
+
+const alertmanagerAPIPath = "/alertmanager/api/v2"
+
+// GetSilence retrieves a silence by its ID.
+func (c *Client) GetSilence(ctx context.Context, id string) (*alertmanager.Silence, error) {
+	s := &alertmanager.Silence{}
+	if err := c.doJSONRequest(ctx, http.MethodGet, alertmanagerAPIPath+"/silence/"+url.PathEscape(id), nil, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ListSilences retrieves all silences of the tenant, including expired ones.
+func (c *Client) ListSilences(ctx context.Context) ([]alertmanager.Silence, error) {
+	silences := []alertmanager.Silence{}
+	if err := c.doJSONRequest(ctx, http.MethodGet, alertmanagerAPIPath+"/silences", nil, &silences); err != nil {
+		return nil, err
+	}
+	return silences, nil
+}
+
+// CreateSilence creates a silence, or replaces it if it has an ID, and
+// returns its ID. The Alertmanager expires an active silence and creates a
+// new one with a new ID if its matchers or start change.
+func (c *Client) CreateSilence(ctx context.Context, s alertmanager.Silence) (string, error) {
+	res := struct {
+		SilenceID string `json:"silenceID"`
+	}{}
+	if err := c.doJSONRequest(ctx, http.MethodPost, alertmanagerAPIPath+"/silences", s, &res); err != nil {
+		return "", err
+	}
+	return res.SilenceID, nil
+}
+
+// DeleteSilence expires a silence.
+func (c *Client) DeleteSilence(ctx context.Context, id string) error {
+	return c.doJSONRequest(ctx, http.MethodDelete, alertmanagerAPIPath+"/silence/"+url.PathEscape(id), nil, nil)
+}
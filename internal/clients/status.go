@@ -0,0 +1,68 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
+)
+
+const errUnmarshalAlerts = "unable to unmarshal alerts from response"
+
+// GetAlertmanagerStatus retrieves the status of the Alertmanager of the
+// tenant.
+func (c *Client) GetAlertmanagerStatus(ctx context.Context) (*alertmanager.Status, error) {
+	s := &alertmanager.Status{}
+	if err := c.doJSONRequest(ctx, http.MethodGet, alertmanagerAPIPath+"/status", nil, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ListReceivers lists the receivers of the configuration the Alertmanager of
+// the tenant runs.
+func (c *Client) ListReceivers(ctx context.Context) ([]alertmanager.ReceiverName, error) {
+	var r []alertmanager.ReceiverName
+	if err := c.doJSONRequest(ctx, http.MethodGet, alertmanagerAPIPath+"/receivers", nil, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ListAlerts lists at most limit alerts of the Alertmanager of the tenant,
+// including silenced and inhibited ones but not the unprocessed ones. The
+// alerts API cannot limit the number of alerts it returns, so the response
+// is only read up to limit alerts.
+func (c *Client) ListAlerts(ctx context.Context, limit int) ([]alertmanager.Alert, error) {
+	q := url.Values{}
+	q.Set("unprocessed", "false")
+
+	res, err := c.doRequest(ctx, http.MethodGet, alertmanagerAPIPath+"/alerts", q, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() //nolint:errcheck // only read from
+
+	dec := json.NewDecoder(res.Body)
+	t, err := dec.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, errUnmarshalAlerts)
+	}
+	if t != json.Delim('[') {
+		return nil, errors.Wrap(errors.Errorf("expected a list, got %v", t), errUnmarshalAlerts)
+	}
+
+	var alerts []alertmanager.Alert
+	for len(alerts) < limit && dec.More() {
+		a := alertmanager.Alert{}
+		if err := dec.Decode(&a); err != nil {
+			return nil, errors.Wrap(err, errUnmarshalAlerts)
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, nil
+}
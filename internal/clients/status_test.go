@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cortexClient "github.com/cortexproject/cortex-tools/pkg/client"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
+)
+
+func TestListAlerts(t *testing.T) {
+	const alerts = `[{"status":{"state":"active"}},{"status":{"state":"suppressed"}},{"status":{"state":"active"}}]`
+
+	type want struct {
+		query  string
+		alerts []alertmanager.Alert
+	}
+
+	cases := map[string]struct {
+		reason string
+		limit  int
+		want   want
+	}{
+		"All": {
+			reason: "All alerts should be returned if there are no more than the limit.",
+			limit:  3,
+			want: want{
+				query: "unprocessed=false",
+				alerts: []alertmanager.Alert{
+					{Status: alertmanager.AlertStatus{State: alertmanager.AlertStateActive}},
+					{Status: alertmanager.AlertStatus{State: alertmanager.AlertStateSuppressed}},
+					{Status: alertmanager.AlertStatus{State: alertmanager.AlertStateActive}},
+				},
+			},
+		},
+		"Limited": {
+			reason: "Only the first alerts up to the limit should be read.",
+			limit:  2,
+			want: want{
+				query: "unprocessed=false",
+				alerts: []alertmanager.Alert{
+					{Status: alertmanager.AlertStatus{State: alertmanager.AlertStateActive}},
+					{Status: alertmanager.AlertStatus{State: alertmanager.AlertStateSuppressed}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := want{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got.query = r.URL.RawQuery
+				w.Write([]byte(alerts)) //nolint:errcheck // the client reports a short response
+			}))
+			defer srv.Close()
+
+			c := NewClient(Config{cortexClientConfig: cortexClient.Config{Address: srv.URL, ID: "tenant"}})
+			var err error
+			got.alerts, err = c.ListAlerts(context.Background(), tc.limit)
+			if err != nil {
+				t.Fatalf("\n%s\nc.ListAlerts(...): %v\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nc.ListAlerts(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
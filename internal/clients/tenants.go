@@ -0,0 +1,83 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/swisscom/provider-cortex/internal/clients/tenants"
+)
+
+const (
+	purgerAPIPath       = "/purger"
+	deleteSeriesAPIPath = "/api/v1/admin/tsdb"
+)
+
+// DeleteTenant requests the deletion of all data of the tenant.
+func (c *Client) DeleteTenant(ctx context.Context) error {
+	return c.doJSONRequest(ctx, http.MethodPost, purgerAPIPath+"/delete_tenant", nil, nil)
+}
+
+// GetTenantDeletionStatus retrieves the progress of the deletion of the
+// tenant.
+func (c *Client) GetTenantDeletionStatus(ctx context.Context) (*tenants.DeletionStatus, error) {
+	s := &tenants.DeletionStatus{}
+	if err := c.doJSONRequest(ctx, http.MethodGet, purgerAPIPath+"/delete_tenant_status", nil, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// AddDeleteRequest requests the deletion of the series selected by matchers
+// in the time range from start to end. The purger defaults an unset start to
+// the beginning of time and an unset end to now.
+func (c *Client) AddDeleteRequest(ctx context.Context, matchers []string, start, end *time.Time) error {
+	q := url.Values{}
+	for _, m := range matchers {
+		q.Add("match[]", m)
+	}
+	if start != nil {
+		q.Set("start", formatTime(*start))
+	}
+	if end != nil {
+		q.Set("end", formatTime(*end))
+	}
+
+	res, err := c.doRequest(ctx, http.MethodPost, prometheusAPIPrefix+deleteSeriesAPIPath+"/delete_series", q, nil)
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+// ListDeleteRequests retrieves all series deletion requests of the tenant.
+func (c *Client) ListDeleteRequests(ctx context.Context) ([]tenants.DeleteRequest, error) {
+	l := []tenants.DeleteRequest{}
+	if err := c.doJSONRequest(ctx, http.MethodGet, prometheusAPIPrefix+deleteSeriesAPIPath+"/delete_series", nil, &l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// CancelDeleteRequest cancels a series deletion request which was not
+// processed yet.
+func (c *Client) CancelDeleteRequest(ctx context.Context, id string) error {
+	q := url.Values{}
+	q.Set("request_id", id)
+
+	res, err := c.doRequest(ctx, http.MethodPost, prometheusAPIPrefix+deleteSeriesAPIPath+"/cancel_delete_request", q, nil)
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+// formatTime formats t as Unix seconds with millisecond precision as accepted
+// by the Prometheus API.
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', 3, 64)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tenants
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+type TenantClient interface {
+	DeleteTenant(ctx context.Context) error
+	GetTenantDeletionStatus(ctx context.Context) (*DeletionStatus, error)
+}
+
+// DeletionStatus is the response of the tenant deletion status API of the
+// purger.
+type DeletionStatus struct {
+	BlocksDeleted bool `json:"blocks_deleted"`
+}
+
+// States of a series deletion request.
+const (
+	DeleteRequestStatusReceived     = "received"
+	DeleteRequestStatusBuildingPlan = "buildingPlan"
+	DeleteRequestStatusDeleting     = "deleting"
+	DeleteRequestStatusProcessed    = "processed"
+)
+
+type SeriesDeletionClient interface {
+	AddDeleteRequest(ctx context.Context, matchers []string, start, end *time.Time) error
+	ListDeleteRequests(ctx context.Context) ([]DeleteRequest, error)
+	CancelDeleteRequest(ctx context.Context, id string) error
+}
+
+// IsNotCancellable returns true if err is the error of the purger refusing to
+// cancel a request, either because its cancel period is over or because it is
+// processed already.
+func IsNotCancellable(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "is not allowed")
+}
+
+// DeleteRequest is a series deletion request of the purger. Only requests
+// with status received can be cancelled.
+type DeleteRequest struct {
+	RequestID string     `json:"request_id"`
+	StartTime model.Time `json:"start_time"`
+	EndTime   model.Time `json:"end_time"`
+	Selectors []string   `json:"selectors"`
+	Status    string     `json:"status"`
+	CreatedAt model.Time `json:"created_at"`
+}
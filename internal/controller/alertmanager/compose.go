@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
+)
+
+const (
+	reasonInvalidConfiguration event.Reason = "InvalidConfiguration"
+
+	// Index AlertManagerConfigurations, AlertmanagerRoutes and
+	// AlertmanagerReceivers by the name of their ProviderConfig.
+	providerConfigIndexKey = "spec.providerConfigRef.name"
+
+	errListRoutes    = "cannot list AlertmanagerRoutes"
+	errListReceivers = "cannot list AlertmanagerReceivers"
+	errMergeConfig   = "cannot merge AlertmanagerRoutes and AlertmanagerReceivers into configuration"
+	errLeftOut       = "left out of the configuration"
+
+	errFmtNotMerged = "not merged into AlertManagerConfiguration %s"
+
+	errFmtPlaceholder = "%s %s contains a ${...} placeholder, which only the secretRefs of the AlertManagerConfiguration can fill"
+)
+
+// compose merges the AlertmanagerRoutes and AlertmanagerReceivers of the
+// ProviderConfigs pcs, which share the tenant of cr, into its configuration
+// cfg. The ones that are invalid are left out and returned as rejections.
+// Placeholders are rejected in them, as they are owned by teams and must not
+// refer to the secrets of the configuration.
+func compose(ctx context.Context, kube client.Reader, cr *v1alpha1.AlertManagerConfiguration, pcs []string, cfg string) (string, []alertmanager.Rejection, error) {
+	var routes []v1alpha1.AlertmanagerRoute
+	var receivers []v1alpha1.AlertmanagerReceiver
+	var rejected []alertmanager.Rejection
+	for _, pc := range pcs {
+		rl := &v1alpha1.AlertmanagerRouteList{}
+		if err := kube.List(ctx, rl, client.MatchingFields{providerConfigIndexKey: pc}); err != nil {
+			return "", nil, errors.Wrap(err, errListRoutes)
+		}
+		for i := range rl.Items {
+			if err := rejectPlaceholders(v1alpha1.AlertmanagerRouteKind, rl.Items[i].GetName(), rl.Items[i].Spec); err != nil {
+				rejected = append(rejected, alertmanager.Rejection{Object: &rl.Items[i], Err: err})
+				continue
+			}
+			routes = append(routes, rl.Items[i])
+		}
+
+		rcvl := &v1alpha1.AlertmanagerReceiverList{}
+		if err := kube.List(ctx, rcvl, client.MatchingFields{providerConfigIndexKey: pc}); err != nil {
+			return "", nil, errors.Wrap(err, errListReceivers)
+		}
+		for i := range rcvl.Items {
+			if err := rejectPlaceholders(v1alpha1.AlertmanagerReceiverKind, rcvl.Items[i].GetName(), rcvl.Items[i].Spec); err != nil {
+				rejected = append(rejected, alertmanager.Rejection{Object: &rcvl.Items[i], Err: err})
+				continue
+			}
+			receivers = append(receivers, rcvl.Items[i])
+		}
+	}
+
+	merged, invalid, err := alertmanager.MergeConfig(cfg, cr.Spec.ForProvider.EnforcedMatcherLabel, routes, receivers)
+	if err != nil {
+		return "", nil, errors.Wrap(err, errMergeConfig)
+	}
+	return merged, append(rejected, invalid...), nil
+}
+
+// reportRejections records a warning event on every AlertmanagerRoute and
+// AlertmanagerReceiver that was left out of the configuration of cr, and on
+// cr itself.
+func (c *external) reportRejections(cr *v1alpha1.AlertManagerConfiguration, rejected []alertmanager.Rejection, secrets map[string]string) {
+	for _, r := range rejected {
+		err := redact(r.Err, secrets)
+		c.recorder.Event(r.Object, event.Warning(reasonInvalidConfiguration, errors.Wrapf(err, errFmtNotMerged, cr.GetName())))
+		c.recorder.Event(cr, event.Warning(reasonInvalidConfiguration, errors.Wrap(err, errLeftOut)))
+	}
+}
+
+// rejectPlaceholders returns an error if the spec of the object kind name
+// contains a placeholder.
+func rejectPlaceholders(kind, name string, spec interface{}) error {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	if placeholder.Match(b) {
+		return errors.Errorf(errFmtPlaceholder, kind, name)
+	}
+	return nil
+}
+
+// providerConfigRefs returns the ProviderConfig of an AlertManagerConfiguration,
+// AlertmanagerRoute or AlertmanagerReceiver.
+func providerConfigRefs(o client.Object) []string {
+	switch cr := o.(type) {
+	case *v1alpha1.AlertManagerConfiguration:
+		if ref := cr.GetProviderConfigReference(); ref != nil {
+			return []string{ref.Name}
+		}
+	case *v1alpha1.AlertmanagerRoute:
+		return []string{cr.Spec.ProviderConfigReference.Name}
+	case *v1alpha1.AlertmanagerReceiver:
+		return []string{cr.Spec.ProviderConfigReference.Name}
+	}
+	return nil
+}
+
+// enqueueConfigurationsOfProviderConfig enqueues the AlertManagerConfigurations
+// of the tenant of the ProviderConfig of an AlertmanagerRoute or
+// AlertmanagerReceiver when it changes.
+func enqueueConfigurationsOfProviderConfig(kube client.Reader) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		var reqs []reconcile.Request
+		for _, name := range providerConfigRefs(o) {
+			pc := &apisv1alpha1.ProviderConfig{}
+			if err := kube.Get(context.Background(), types.NamespacedName{Name: name}, pc); err != nil {
+				return nil
+			}
+			pcs, err := tenantProviderConfigs(context.Background(), kube, pc)
+			if err != nil {
+				return nil
+			}
+			for _, p := range pcs {
+				l := &v1alpha1.AlertManagerConfigurationList{}
+				if err := kube.List(context.Background(), l, client.MatchingFields{providerConfigIndexKey: p.GetName()}); err != nil {
+					return nil
+				}
+				for _, c := range l.Items {
+					reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: c.GetName()}})
+				}
+			}
+		}
+		return reqs
+	}
+}
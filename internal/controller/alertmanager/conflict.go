@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
+)
+
+const (
+	// Index ProviderConfigs by their <address>|<tenantId>. All of them share
+	// the Alertmanager configuration of the tenant.
+	tenantIndexKey = "spec.address+tenantId"
+
+	errListProviderConfigs = "cannot list ProviderConfigs of the tenant"
+	errListConfigurations  = "cannot list AlertManagerConfigurations of the tenant"
+
+	errFmtConflict = "the Alertmanager configuration of tenant %s at %s is managed by AlertManagerConfiguration %s"
+)
+
+// tenantKey returns the key of the tenant of a ProviderConfig.
+func tenantKey(pc *apisv1alpha1.ProviderConfig) string {
+	return strings.TrimSuffix(strings.ToLower(pc.Spec.Address), "/") + "|" + pc.Spec.TenantID
+}
+
+// tenantKeys returns the tenant of a ProviderConfig.
+func tenantKeys(o client.Object) []string {
+	pc, ok := o.(*apisv1alpha1.ProviderConfig)
+	if !ok {
+		return nil
+	}
+	return []string{tenantKey(pc)}
+}
+
+// tenantProviderConfigs returns the ProviderConfigs of the tenant of pc,
+// including pc.
+func tenantProviderConfigs(ctx context.Context, kube client.Reader, pc *apisv1alpha1.ProviderConfig) ([]apisv1alpha1.ProviderConfig, error) {
+	pcs := &apisv1alpha1.ProviderConfigList{}
+	if err := kube.List(ctx, pcs, client.MatchingFields{tenantIndexKey: tenantKey(pc)}); err != nil {
+		return nil, errors.Wrap(err, errListProviderConfigs)
+	}
+	for _, p := range pcs.Items {
+		if p.GetName() == pc.GetName() {
+			return pcs.Items, nil
+		}
+	}
+	return append(pcs.Items, *pc), nil
+}
+
+// names returns the names of the ProviderConfigs pcs.
+func names(pcs []apisv1alpha1.ProviderConfig) []string {
+	n := make([]string, 0, len(pcs))
+	for _, p := range pcs {
+		n = append(n, p.GetName())
+	}
+	return n
+}
+
+// findConflict returns the name of the AlertManagerConfiguration that
+// manages the Alertmanager configuration of the tenant of the ProviderConfigs
+// pcs in place of cr, or an empty string if cr manages it. The oldest
+// AlertManagerConfiguration of a tenant manages its configuration, the ones
+// created after it are blocked.
+func findConflict(ctx context.Context, kube client.Reader, cr *v1alpha1.AlertManagerConfiguration, pcs []apisv1alpha1.ProviderConfig) (string, error) {
+	owner := cr
+	for _, p := range pcs {
+		l := &v1alpha1.AlertManagerConfigurationList{}
+		if err := kube.List(ctx, l, client.MatchingFields{providerConfigIndexKey: p.GetName()}); err != nil {
+			return "", errors.Wrap(err, errListConfigurations)
+		}
+		for i := range l.Items {
+			if olderThan(&l.Items[i], owner) {
+				owner = &l.Items[i]
+			}
+		}
+	}
+
+	if owner.GetName() == cr.GetName() {
+		return "", nil
+	}
+	return owner.GetName(), nil
+}
+
+// olderThan reports whether a was created before b. Objects created within
+// the same second are ordered by name.
+func olderThan(a, b *v1alpha1.AlertManagerConfiguration) bool {
+	ta, tb := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !ta.Equal(&tb) {
+		return ta.Before(&tb)
+	}
+	return a.GetName() < b.GetName()
+}
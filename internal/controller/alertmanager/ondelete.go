@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
+)
+
+const (
+	reasonRestoredBaseline event.Reason = "RestoredBaseline"
+
+	errNoBaselineRef   = "onDelete.baselineRef must be set to restore a baseline"
+	errInvalidBaseline = "invalid baseline configuration"
+	errRestoreBaseline = "cannot restore baseline configuration"
+
+	errFmtGetBaseline = "cannot get baseline ConfigMap %s/%s"
+	errFmtBaselineKey = "key %q not found in baseline ConfigMap %s/%s"
+)
+
+// restoresBaseline reports whether the configuration of cr is replaced with a
+// baseline rather than deleted when cr is deleted.
+func restoresBaseline(cr *v1alpha1.AlertManagerConfiguration) bool {
+	od := cr.Spec.ForProvider.OnDelete
+	return od != nil && od.Action == v1alpha1.OnDeleteRestore
+}
+
+// getBaseline returns the baseline configuration of cr.
+func getBaseline(ctx context.Context, kube client.Reader, cr *v1alpha1.AlertManagerConfiguration) (string, error) {
+	ref := cr.Spec.ForProvider.OnDelete.BaselineRef
+	if ref == nil {
+		return "", errors.New(errNoBaselineRef)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+		return "", errors.Wrapf(err, errFmtGetBaseline, ref.Namespace, ref.Name)
+	}
+	v, ok := cm.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errFmtBaselineKey, ref.Key, ref.Namespace, ref.Name)
+	}
+	return v, nil
+}
+
+// restoreBaseline replaces the configuration of the tenant with the baseline
+// of cr and records which baseline was applied.
+func (c *external) restoreBaseline(ctx context.Context, cr *v1alpha1.AlertManagerConfiguration) error {
+	baseline, err := getBaseline(ctx, c.kube, cr)
+	if err != nil {
+		return err
+	}
+	if err := alertmanager.ValidateConfig(baseline); err != nil {
+		return errors.Wrap(err, errInvalidBaseline)
+	}
+	if err := c.service.CreateAlertmanagerConfig(ctx, baseline, nil); err != nil {
+		return errors.Wrap(err, errRestoreBaseline)
+	}
+
+	ref := cr.Spec.ForProvider.OnDelete.BaselineRef
+	c.recorder.Event(cr, event.Normal(reasonRestoredBaseline, fmt.Sprintf("Restored baseline configuration from key %q of ConfigMap %s/%s", ref.Key, ref.Namespace, ref.Name)))
+	return nil
+}
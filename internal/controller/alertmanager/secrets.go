@@ -0,0 +1,219 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
+)
+
+const (
+	// Index AlertManagerConfigurations by the <namespace>/<name> of the
+	// Secrets they read placeholder values from.
+	secretIndexKey = "spec.forProvider.secretRefs.secretKeyRef"
+
+	// redacted replaces secret values in errors.
+	redacted = "<secret>"
+
+	errParseConfig   = "cannot parse configuration"
+	errInjectSecrets = "cannot inject secrets into configuration"
+
+	errFmtGetSecret = "cannot get Secret %s/%s of %s"
+	errFmtSecretKey = "key %q not found in Secret %s/%s of %s"
+)
+
+// placeholder matches ${name}. The names of secretRefs cannot contain a dot,
+// the placeholders of the secret references of the global parameters do.
+var placeholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_.]*)\}`)
+
+// A secretRef is a secretRef of an AlertManagerConfiguration or a secret
+// reference of its global parameters, and the field it is set in.
+type secretRef struct {
+	field string
+	v1alpha1.ConfigSecretRef
+}
+
+// allSecretRefs returns the secretRefs of cr and the secret references of the
+// global parameters of its config.
+func allSecretRefs(cr *v1alpha1.AlertManagerConfiguration) []secretRef {
+	var refs []secretRef
+	for i, ref := range cr.Spec.ForProvider.SecretRefs {
+		refs = append(refs, secretRef{field: fmt.Sprintf("secretRefs[%d]", i), ConfigSecretRef: ref})
+	}
+	for _, ref := range alertmanager.GlobalSecretRefs(cr.Spec.ForProvider.Config) {
+		// The placeholders of the global parameters are named after them.
+		refs = append(refs, secretRef{field: "config." + ref.Name + "SecretRef", ConfigSecretRef: ref})
+	}
+	return refs
+}
+
+// resolveSecrets returns the values of the secretRefs of cr and of the secret
+// references of its global parameters by placeholder name.
+func resolveSecrets(ctx context.Context, kube client.Reader, cr *v1alpha1.AlertManagerConfiguration) (map[string]string, error) {
+	refs := allSecretRefs(cr)
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		sel := ref.SecretKeyRef
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: sel.Namespace, Name: sel.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, errFmtGetSecret, sel.Namespace, sel.Name, ref.field)
+		}
+		v, ok := s.Data[sel.Key]
+		if !ok {
+			return nil, errors.Errorf(errFmtSecretKey, sel.Key, sel.Namespace, sel.Name, ref.field)
+		}
+		values[ref.Name] = string(v)
+	}
+	return values, nil
+}
+
+// replacePlaceholders replaces the placeholders of s that values holds.
+// Others are left as they are.
+func replacePlaceholders(s string, values map[string]string) string {
+	return placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		if v, ok := values[placeholder.FindStringSubmatch(m)[1]]; ok {
+			return v
+		}
+		return m
+	})
+}
+
+// injectSecrets replaces the placeholders of an alert manager config file.
+// They are replaced in the scalars of the parsed file, so values are quoted
+// as YAML requires.
+func injectSecrets(cfg string, values map[string]string) (string, error) {
+	if len(values) == 0 {
+		return cfg, nil
+	}
+
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal([]byte(cfg), &doc); err != nil {
+		return "", errors.Wrap(err, errParseConfig)
+	}
+	injectNode(&doc, values)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return "", errors.Wrap(err, errInjectSecrets)
+	}
+	if err := enc.Close(); err != nil {
+		return "", errors.Wrap(err, errInjectSecrets)
+	}
+	return buf.String(), nil
+}
+
+func injectNode(n *yaml.Node, values map[string]string) {
+	if n.Kind == yaml.ScalarNode && placeholder.MatchString(n.Value) {
+		n.Value = replacePlaceholders(n.Value, values)
+		// The value may no longer be what the tag was resolved from, e.g. a
+		// number; it is a string either way.
+		n.Tag = "!!str"
+	}
+	for _, c := range n.Content {
+		injectNode(c, values)
+	}
+}
+
+// injectTemplateSecrets replaces the placeholders of the template files.
+func injectTemplateSecrets(templates map[string]string, values map[string]string) map[string]string {
+	if len(values) == 0 || templates == nil {
+		return templates
+	}
+	out := make(map[string]string, len(templates))
+	for name, t := range templates {
+		out[name] = replacePlaceholders(t, values)
+	}
+	return out
+}
+
+// redact replaces the secret values in the message of err, so that it can
+// be written to conditions, events and logs.
+func redact(err error, values map[string]string) error {
+	if err == nil || len(values) == 0 {
+		return err
+	}
+
+	// Replace longer values first, so that values containing others are
+	// replaced entirely.
+	secrets := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			secrets = append(secrets, v)
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+
+	msg := err.Error()
+	for _, v := range secrets {
+		msg = strings.ReplaceAll(msg, v, redacted)
+	}
+	if msg == err.Error() {
+		return err
+	}
+	return errors.New(msg)
+}
+
+// secretRefs returns the Secrets an AlertManagerConfiguration reads
+// placeholder values from.
+func secretRefs(o client.Object) []string {
+	cr, ok := o.(*v1alpha1.AlertManagerConfiguration)
+	if !ok {
+		return nil
+	}
+	var refs []string
+	for _, ref := range allSecretRefs(cr) {
+		refs = append(refs, types.NamespacedName{Namespace: ref.SecretKeyRef.Namespace, Name: ref.SecretKeyRef.Name}.String())
+	}
+	return refs
+}
+
+// enqueueConfigurationsFor enqueues every AlertManagerConfiguration that
+// reads placeholder values from a Secret when it changes.
+func enqueueConfigurationsFor(kube client.Reader, indexKey string) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		l := &v1alpha1.AlertManagerConfigurationList{}
+		if err := kube.List(context.Background(), l, client.MatchingFields{indexKey: client.ObjectKeyFromObject(o).String()}); err != nil {
+			return nil
+		}
+		reqs := make([]reconcile.Request, 0, len(l.Items))
+		for _, c := range l.Items {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: c.GetName()}})
+		}
+		return reqs
+	}
+}
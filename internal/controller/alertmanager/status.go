@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
+)
+
+const (
+	errGetStatus            = "cannot get Alertmanager status"
+	errListRunningReceivers = "cannot list Alertmanager receivers"
+	errListAlerts           = "cannot list Alertmanager alerts"
+
+	statusSuccess = "success"
+	statusError   = "error"
+
+	// maxObservedAlerts bounds the number of alerts that are counted, as the
+	// alerts API returns all alerts of the tenant.
+	maxObservedAlerts = 1000
+)
+
+// observeStatus returns the state of the Alertmanager of the tenant. An
+// Alertmanager that has not loaded the configuration of the tenant yet has
+// no state. A failure to query one of the read only endpoints is recorded in
+// the returned state, with the values of secrets redacted, but does not fail
+// the observation, so that it cannot block updates and the deletion of the
+// configuration.
+func (c *external) observeStatus(ctx context.Context, desired string, secrets map[string]string) v1alpha1.AlertManagerConfigurationObservation {
+	obs := v1alpha1.AlertManagerConfigurationObservation{}
+	var errs []error
+
+	status, err := c.service.GetAlertmanagerStatus(ctx)
+	if isErrConfigurationNotFound(err) {
+		return obs
+	}
+	if err != nil {
+		errs = append(errs, errors.Wrap(err, errGetStatus))
+	} else {
+		sum := sha256.Sum256([]byte(status.Config.Original))
+		obs.ConfigHash = hex.EncodeToString(sum[:])
+		obs.ConfigApplied = alertmanager.RunsConfig(desired, status.Config.Original)
+		obs.ClusterStatus = status.Cluster.Status
+		for _, p := range status.Cluster.Peers {
+			obs.ClusterPeers = append(obs.ClusterPeers, v1alpha1.ClusterPeer{Name: p.Name, Address: p.Address})
+		}
+		sort.Slice(obs.ClusterPeers, func(i, j int) bool { return obs.ClusterPeers[i].Name < obs.ClusterPeers[j].Name })
+	}
+
+	receivers, err := c.service.ListReceivers(ctx)
+	if err != nil && !isErrConfigurationNotFound(err) {
+		errs = append(errs, errors.Wrap(err, errListRunningReceivers))
+	}
+	for _, r := range receivers {
+		obs.Receivers = append(obs.Receivers, r.Name)
+	}
+	sort.Strings(obs.Receivers)
+
+	alerts, err := c.service.ListAlerts(ctx, maxObservedAlerts)
+	if err != nil && !isErrConfigurationNotFound(err) {
+		errs = append(errs, errors.Wrap(err, errListAlerts))
+	}
+	for _, a := range alerts {
+		switch a.Status.State {
+		case alertmanager.AlertStateActive:
+			obs.ActiveAlerts++
+		case alertmanager.AlertStateSuppressed:
+			obs.SuppressedAlerts++
+		}
+	}
+
+	obs.Status = statusSuccess
+	if len(errs) > 0 {
+		obs.Status = statusError
+		obs.Error = redact(kerrors.NewAggregate(errs), secrets).Error()
+	}
+	return obs
+}
@@ -23,6 +23,10 @@ import (
 	"github.com/swisscom/provider-cortex/internal/controller/alertmanager"
 	"github.com/swisscom/provider-cortex/internal/controller/config"
 	"github.com/swisscom/provider-cortex/internal/controller/rulegroup"
+	"github.com/swisscom/provider-cortex/internal/controller/rulenamespace"
+	"github.com/swisscom/provider-cortex/internal/controller/seriesdeletionrequest"
+	"github.com/swisscom/provider-cortex/internal/controller/silence"
+	"github.com/swisscom/provider-cortex/internal/controller/tenantdeletion"
 )
 
 // Setup creates all cortex controllers with the supplied logger and adds them to
@@ -31,7 +35,11 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 	for _, setup := range []func(ctrl.Manager, controller.Options) error{
 		config.Setup,
 		rulegroup.Setup,
+		rulenamespace.Setup,
 		alertmanager.Setup,
+		silence.Setup,
+		tenantdeletion.Setup,
+		seriesdeletionrequest.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
 			return err
@@ -0,0 +1,364 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prometheusrule converts PrometheusRule objects of the Prometheus
+// Operator into RuleGroup managed resources.
+package prometheusrule
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+)
+
+const (
+	// Labels of the RuleGroups generated from a PrometheusRule. They are
+	// used to find the RuleGroups of a PrometheusRule once it is deleted.
+	// The name is shortened to the length of a label value if necessary.
+	labelSourceNamespace = "cortex.crossplane.io/prometheusrule-namespace"
+	labelSourceName      = "cortex.crossplane.io/prometheusrule-name"
+
+	// annotationSource holds the <namespace>/<name> of the PrometheusRule a
+	// RuleGroup was generated from.
+	annotationSource = "cortex.crossplane.io/prometheusrule"
+
+	// legacyFinalizer was added to PrometheusRules by earlier versions to
+	// keep them until their RuleGroups were deleted. It kept them forever once
+	// the controller was disabled, so it is removed again. The RuleGroups of
+	// a deleted PrometheusRule are found by their labels instead.
+	legacyFinalizer = "cortex.crossplane.io/prometheusrule"
+
+	// maxNameLength is the maximum length of the name of a RuleGroup.
+	maxNameLength = 253
+
+	// maxLabelValueLength is the maximum length of a label value.
+	maxLabelValueLength = 63
+
+	errGetPrometheusRule    = "cannot get PrometheusRule"
+	errRemoveFinalizer      = "cannot remove finalizer from PrometheusRule"
+	errParsePrometheusRule  = "cannot parse spec of PrometheusRule"
+	errListRuleGroups       = "cannot list RuleGroups of PrometheusRule"
+	errParseNamespaceFormat = "cannot parse ruler namespace format"
+	errRenderNamespace      = "cannot render ruler namespace"
+	errFmtApplyRuleGroup    = "cannot apply RuleGroup %s"
+	errFmtDeleteRuleGroup   = "cannot delete RuleGroup %s"
+	errFmtNotGenerated      = "RuleGroup %s was not generated from a PrometheusRule"
+	errFmtOtherSource       = "RuleGroup %s was generated from PrometheusRule %s"
+)
+
+// PrometheusRuleGroupVersionKind is the kind of the PrometheusRule objects
+// of the Prometheus Operator.
+var PrometheusRuleGroupVersionKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+// invalidNameChars matches the characters that are not allowed in the name
+// of a RuleGroup.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// Options configure the RuleGroups generated from PrometheusRules.
+type Options struct {
+	// ProviderConfigName is the ProviderConfig of every generated RuleGroup.
+	ProviderConfigName string
+
+	// NamespaceFormat is a Go template rendering the ruler namespace of the
+	// rule groups of a PrometheusRule from its .Namespace and .Name.
+	NamespaceFormat string
+}
+
+// Setup adds a controller that generates a RuleGroup for every rule group of
+// every PrometheusRule. The PrometheusRule CRD must be installed.
+func Setup(mgr ctrl.Manager, o controller.Options, po Options) error {
+	name := "prometheusrule/" + strings.ToLower(PrometheusRuleGroupVersionKind.GroupKind().String())
+
+	ns, err := template.New("namespace").Option("missingkey=error").Parse(po.NamespaceFormat)
+	if err != nil {
+		return errors.Wrap(err, errParseNamespaceFormat)
+	}
+
+	r := &Reconciler{
+		client:         mgr.GetClient(),
+		log:            o.Logger.WithValues("controller", name),
+		finalizer:      resource.NewAPIFinalizer(mgr.GetClient(), legacyFinalizer),
+		providerConfig: po.ProviderConfigName,
+		namespace:      ns,
+	}
+
+	pr := &unstructured.Unstructured{}
+	pr.SetGroupVersionKind(PrometheusRuleGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(pr).
+		Watches(&source.Kind{Type: &v1alpha1.RuleGroup{}}, handler.EnqueueRequestsFromMapFunc(sourceOf)).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// sourceOf enqueues the PrometheusRule a RuleGroup was generated from, so
+// that changes to generated RuleGroups are reverted and the RuleGroups of a
+// PrometheusRule that was deleted while the controller was not running are
+// deleted once it runs again.
+func sourceOf(o client.Object) []reconcile.Request {
+	namespace, name, ok := strings.Cut(o.GetAnnotations()[annotationSource], "/")
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
+
+// A Reconciler generates the RuleGroups of a PrometheusRule and deletes them
+// once the PrometheusRule is deleted. It does not keep PrometheusRules with a
+// finalizer, so that they can be deleted whether the controller runs or not.
+type Reconciler struct {
+	client         client.Client
+	log            logging.Logger
+	finalizer      resource.Finalizer
+	providerConfig string
+	namespace      *template.Template
+}
+
+// Reconcile a PrometheusRule.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	pr := &unstructured.Unstructured{}
+	pr.SetGroupVersionKind(PrometheusRuleGroupVersionKind)
+	err := r.client.Get(ctx, req.NamespacedName, pr)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return reconcile.Result{}, errors.Wrap(err, errGetPrometheusRule)
+	}
+
+	// The RuleGroups of a deleted PrometheusRule are all stale.
+	var desired []*v1alpha1.RuleGroup
+	exists := err == nil
+	switch {
+	case !exists:
+	case meta.WasDeleted(pr):
+		log.Debug("PrometheusRule is being deleted")
+	default:
+		desired, err = r.generate(pr)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	keep := make(map[string]bool, len(desired))
+	for _, rg := range desired {
+		if err := r.apply(ctx, rg); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, errFmtApplyRuleGroup, rg.GetName())
+		}
+		keep[rg.GetName()] = true
+	}
+
+	l := &v1alpha1.RuleGroupList{}
+	if err := r.client.List(ctx, l, client.MatchingLabels{labelSourceNamespace: req.Namespace, labelSourceName: labelValue(req.Name)}); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, errListRuleGroups)
+	}
+	for i := range l.Items {
+		rg := &l.Items[i]
+		if keep[rg.GetName()] {
+			continue
+		}
+		if err := r.client.Delete(ctx, rg); client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, errors.Wrapf(err, errFmtDeleteRuleGroup, rg.GetName())
+		}
+		log.Debug("Deleted stale RuleGroup", "name", rg.GetName())
+	}
+
+	if exists && meta.FinalizerExists(pr, legacyFinalizer) {
+		if err := r.finalizer.RemoveFinalizer(ctx, pr); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, errRemoveFinalizer)
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// apply creates the RuleGroup or replaces the spec of the existing one. The
+// external name is only set on creation. Existing RuleGroups that were not
+// generated from the same PrometheusRule are not changed.
+func (r *Reconciler) apply(ctx context.Context, desired *v1alpha1.RuleGroup) error {
+	rg := &v1alpha1.RuleGroup{}
+	rg.SetName(desired.GetName())
+	_, err := controllerutil.CreateOrUpdate(ctx, r.client, rg, func() error {
+		if !rg.CreationTimestamp.IsZero() {
+			if err := sameSource(rg, desired); err != nil {
+				return err
+			}
+		}
+		meta.AddLabels(rg, desired.GetLabels())
+		meta.AddAnnotations(rg, map[string]string{annotationSource: desired.GetAnnotations()[annotationSource]})
+		if meta.GetExternalName(rg) == "" {
+			meta.SetExternalName(rg, meta.GetExternalName(desired))
+		}
+		rg.Spec.ForProvider = desired.Spec.ForProvider
+		rg.Spec.ProviderConfigReference = desired.Spec.ProviderConfigReference
+		return nil
+	})
+	return err
+}
+
+// sameSource returns an error if the existing RuleGroup rg was not generated
+// from the PrometheusRule of desired.
+func sameSource(rg, desired *v1alpha1.RuleGroup) error {
+	l := rg.GetLabels()
+	if l[labelSourceName] == "" {
+		return errors.Errorf(errFmtNotGenerated, rg.GetName())
+	}
+	if src, ok := rg.GetAnnotations()[annotationSource]; ok && src != desired.GetAnnotations()[annotationSource] {
+		return errors.Errorf(errFmtOtherSource, rg.GetName(), src)
+	}
+	dl := desired.GetLabels()
+	if l[labelSourceNamespace] != dl[labelSourceNamespace] || l[labelSourceName] != dl[labelSourceName] {
+		return errors.Errorf(errFmtOtherSource, rg.GetName(), l[labelSourceNamespace]+"/"+l[labelSourceName])
+	}
+	return nil
+}
+
+// prometheusRuleSpec is the spec of a PrometheusRule.
+type prometheusRuleSpec struct {
+	Groups []prometheusRuleGroup `json:"groups"`
+}
+
+type prometheusRuleGroup struct {
+	Name     string           `json:"name"`
+	Interval *string          `json:"interval,omitempty"`
+	Limit    *int             `json:"limit,omitempty"`
+	Rules    []prometheusRule `json:"rules"`
+}
+
+type prometheusRule struct {
+	Record        string             `json:"record,omitempty"`
+	Alert         string             `json:"alert,omitempty"`
+	Expr          intstr.IntOrString `json:"expr"`
+	For           *string            `json:"for,omitempty"`
+	KeepFiringFor *string            `json:"keep_firing_for,omitempty"`
+	Labels        map[string]string  `json:"labels,omitempty"`
+	Annotations   map[string]string  `json:"annotations,omitempty"`
+}
+
+// generate returns a RuleGroup for every rule group of a PrometheusRule.
+func (r *Reconciler) generate(pr *unstructured.Unstructured) ([]*v1alpha1.RuleGroup, error) {
+	spec := prometheusRuleSpec{}
+	if s, ok := pr.Object["spec"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(s, &spec); err != nil {
+			return nil, errors.Wrap(err, errParsePrometheusRule)
+		}
+	}
+
+	ns := &strings.Builder{}
+	if err := r.namespace.Execute(ns, struct{ Namespace, Name string }{pr.GetNamespace(), pr.GetName()}); err != nil {
+		return nil, errors.Wrap(err, errRenderNamespace)
+	}
+
+	groups := make([]*v1alpha1.RuleGroup, 0, len(spec.Groups))
+	for _, g := range spec.Groups {
+		rg := &v1alpha1.RuleGroup{}
+		rg.SetName(ruleGroupName(pr.GetNamespace(), pr.GetName(), g.Name))
+		rg.SetLabels(map[string]string{labelSourceNamespace: pr.GetNamespace(), labelSourceName: labelValue(pr.GetName())})
+		meta.AddAnnotations(rg, map[string]string{annotationSource: pr.GetNamespace() + "/" + pr.GetName()})
+		meta.SetExternalName(rg, g.Name)
+		rg.Spec.ProviderConfigReference = &xpv1.Reference{Name: r.providerConfig}
+		rg.Spec.ForProvider = v1alpha1.RuleGroupParameters{
+			Namespace: ns.String(),
+			RuleGroupSettings: v1alpha1.RuleGroupSettings{
+				Interval: g.Interval,
+				Limit:    g.Limit,
+			},
+			Rules: make([]v1alpha1.RuleNode, 0, len(g.Rules)),
+		}
+		for _, rule := range g.Rules {
+			rg.Spec.ForProvider.Rules = append(rg.Spec.ForProvider.Rules, v1alpha1.RuleNode{
+				Record:        optional(rule.Record),
+				Alert:         optional(rule.Alert),
+				Expr:          rule.Expr.String(),
+				For:           rule.For,
+				KeepFiringFor: rule.KeepFiringFor,
+				Labels:        rule.Labels,
+				Annotations:   rule.Annotations,
+			})
+		}
+		groups = append(groups, rg)
+	}
+	return groups, nil
+}
+
+// ruleGroupName returns a valid and unique name for the RuleGroup of a rule
+// group of a PrometheusRule. A hash of the namespace and name of the
+// PrometheusRule and the name of the rule group is appended, as joining them
+// is ambiguous and the name of the rule group may have to be changed to be
+// valid.
+func ruleGroupName(namespace, name, group string) string {
+	sanitized := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(group), "-"), "-.")
+
+	// Namespaces and names cannot contain a slash, so the input of the hash
+	// is unique.
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(namespace + "/" + name + "/" + group))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+
+	n := fmt.Sprintf("%s-%s-%s", namespace, name, sanitized)
+	if len(n)+len(suffix) > maxNameLength {
+		n = strings.TrimRight(n[:maxNameLength-len(suffix)], "-.")
+	}
+	return n + suffix
+}
+
+// labelValue returns name if it fits into a label value, and otherwise its
+// start followed by a hash of the whole name.
+func labelValue(name string) string {
+	if len(name) <= maxLabelValueLength {
+		return name
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	return strings.TrimRight(name[:maxLabelValueLength-len(suffix)], "-.") + suffix
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheusrule
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+func strPtr(s string) *string { return &s }
+
+func newPrometheusRule(groups ...interface{}) *unstructured.Unstructured {
+	pr := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"groups": groups},
+	}}
+	pr.SetGroupVersionKind(PrometheusRuleGroupVersionKind)
+	pr.SetNamespace("team-a")
+	pr.SetName("api")
+	return pr
+}
+
+func reconciler(t *testing.T, kube client.Client) *Reconciler {
+	t.Helper()
+	return &Reconciler{
+		client:         kube,
+		log:            logging.NewNopLogger(),
+		finalizer:      resource.NewAPIFinalizer(kube, legacyFinalizer),
+		providerConfig: "cortex",
+		namespace:      template.Must(template.New("namespace").Option("missingkey=error").Parse("{{ .Namespace }}-{{ .Name }}")),
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	pr := newPrometheusRule(
+		map[string]interface{}{
+			"name":     "api.rules",
+			"interval": "1m",
+			"rules": []interface{}{
+				map[string]interface{}{"record": "job:up:sum", "expr": "sum by (job) (up)"},
+				map[string]interface{}{
+					"alert":       "Down",
+					"expr":        "up == 0",
+					"for":         "5m",
+					"labels":      map[string]interface{}{"severity": "critical"},
+					"annotations": map[string]interface{}{"summary": "{{ $labels.instance }} is down"},
+				},
+			},
+		},
+		map[string]interface{}{
+			"name":  "constant",
+			"limit": int64(10),
+			"rules": []interface{}{
+				map[string]interface{}{"record": "one", "expr": int64(1)},
+			},
+		},
+	)
+
+	want := []*v1alpha1.RuleGroup{
+		{
+			Spec: v1alpha1.RuleGroupSpec{
+				ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "cortex"}},
+				ForProvider: v1alpha1.RuleGroupParameters{
+					Namespace:         "team-a-api",
+					RuleGroupSettings: v1alpha1.RuleGroupSettings{Interval: strPtr("1m")},
+					Rules: []v1alpha1.RuleNode{
+						{Record: strPtr("job:up:sum"), Expr: "sum by (job) (up)"},
+						{
+							Alert:       strPtr("Down"),
+							Expr:        "up == 0",
+							For:         strPtr("5m"),
+							Labels:      map[string]string{"severity": "critical"},
+							Annotations: map[string]string{"summary": "{{ $labels.instance }} is down"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Spec: v1alpha1.RuleGroupSpec{
+				ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "cortex"}},
+				ForProvider: v1alpha1.RuleGroupParameters{
+					Namespace:         "team-a-api",
+					RuleGroupSettings: v1alpha1.RuleGroupSettings{Limit: func() *int { i := 10; return &i }()},
+					Rules:             []v1alpha1.RuleNode{{Record: strPtr("one"), Expr: "1"}},
+				},
+			},
+		},
+	}
+	for i, n := range []string{"api.rules", "constant"} {
+		want[i].SetName(map[string]string{"api.rules": "team-a-api-api.rules-e29c64bf", "constant": "team-a-api-constant-9e656632"}[n])
+		want[i].SetLabels(map[string]string{labelSourceNamespace: "team-a", labelSourceName: "api"})
+		meta.AddAnnotations(want[i], map[string]string{annotationSource: "team-a/api"})
+		meta.SetExternalName(want[i], n)
+	}
+
+	got, err := reconciler(t, nil).generate(pr)
+	if err != nil {
+		t.Fatalf("generate(...): %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("generate(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestRuleGroupName(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		namespace string
+		name      string
+		group     string
+		want      string
+	}{
+		"Valid": {
+			reason:    "A valid group name should be used as is and made unique with a hash.",
+			namespace: "team-a",
+			name:      "api",
+			group:     "node.rules",
+			want:      "team-a-api-node.rules-112b2ff7",
+		},
+		"Sanitized": {
+			reason:    "An invalid group name should be sanitized and made unique with a hash.",
+			namespace: "team-a",
+			name:      "api",
+			group:     "Node Exporter",
+			want:      "team-a-api-node-exporter-765e009f",
+		},
+		"DashInNamespace": {
+			reason:    "A PrometheusRule whose namespace and name join like the ones of another should have another name.",
+			namespace: "a-b",
+			name:      "c",
+			group:     "g",
+			want:      "a-b-c-g-d511823b",
+		},
+		"DashInName": {
+			reason:    "A PrometheusRule whose namespace and name join like the ones of another should have another name.",
+			namespace: "a",
+			name:      "b-c",
+			group:     "g",
+			want:      "a-b-c-g-62ff801b",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ruleGroupName(tc.namespace, tc.name, tc.group)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nruleGroupName(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		name   string
+		want   string
+	}{
+		"Short": {
+			reason: "A name that fits into a label value should be used as is.",
+			name:   "api",
+			want:   "api",
+		},
+		"Long": {
+			reason: "A name that is too long for a label value should be shortened and made unique with a hash.",
+			name:   strings.Repeat("a", 70),
+			want:   strings.Repeat("a", 54) + "-5904740b",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := labelValue(tc.name)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nlabelValue(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSourceOf(t *testing.T) {
+	rg := &v1alpha1.RuleGroup{}
+	meta.AddAnnotations(rg, map[string]string{annotationSource: "team-a/api"})
+
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "api"}}}
+	if diff := cmp.Diff(want, sourceOf(rg)); diff != "" {
+		t.Errorf("sourceOf(...): -want, +got:\n%s", diff)
+	}
+	if got := sourceOf(&v1alpha1.RuleGroup{}); got != nil {
+		t.Errorf("sourceOf(...): want nil for a RuleGroup without source, got %v", got)
+	}
+}
+
+func TestApply(t *testing.T) {
+	generated := func(namespace, name string) *v1alpha1.RuleGroup {
+		rg := &v1alpha1.RuleGroup{}
+		rg.SetName("team-a-api-node.rules-112b2ff7")
+		rg.SetLabels(map[string]string{labelSourceNamespace: namespace, labelSourceName: name})
+		meta.AddAnnotations(rg, map[string]string{annotationSource: namespace + "/" + name})
+		return rg
+	}
+
+	cases := map[string]struct {
+		reason   string
+		existing *v1alpha1.RuleGroup
+		want     error
+	}{
+		"SameSource": {
+			reason:   "A RuleGroup generated from the same PrometheusRule should be updated.",
+			existing: generated("team-a", "api"),
+		},
+		"OtherSource": {
+			reason:   "A RuleGroup generated from another PrometheusRule should not be adopted.",
+			existing: generated("team-b", "api"),
+			want:     errors.Errorf(errFmtOtherSource, "team-a-api-node.rules-112b2ff7", "team-b/api"),
+		},
+		"NotGenerated": {
+			reason:   "A RuleGroup that was not generated from a PrometheusRule should not be adopted.",
+			existing: &v1alpha1.RuleGroup{ObjectMeta: metav1.ObjectMeta{Name: "team-a-api-node.rules-112b2ff7"}},
+			want:     errors.Errorf(errFmtNotGenerated, "team-a-api-node.rules-112b2ff7"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					tc.existing.DeepCopyInto(obj.(*v1alpha1.RuleGroup))
+					obj.SetCreationTimestamp(metav1.Now())
+					return nil
+				},
+				MockUpdate: test.NewMockUpdateFn(nil),
+			}
+			err := reconciler(t, kube).apply(context.Background(), generated("team-a", "api"))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\napply(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcileDeleted(t *testing.T) {
+	var deleted []string
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Group: "monitoring.coreos.com", Resource: "prometheusrules"}, "api")),
+		MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			lo := &client.ListOptions{}
+			lo.ApplyOptions(opts)
+			if lo.LabelSelector.String() != labelSourceName+"=api,"+labelSourceNamespace+"=team-a" {
+				t.Errorf("List(...): unexpected selector %q", lo.LabelSelector.String())
+			}
+			l := list.(*v1alpha1.RuleGroupList)
+			l.Items = []v1alpha1.RuleGroup{{}, {}}
+			l.Items[0].SetName("team-a-api-node.rules")
+			l.Items[1].SetName("team-a-api-api.rules")
+			return nil
+		},
+		MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+			deleted = append(deleted, obj.GetName())
+			return nil
+		},
+	}
+
+	_, err := reconciler(t, kube).Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "api"}})
+	if err != nil {
+		t.Fatalf("Reconcile(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{"team-a-api-node.rules", "team-a-api-api.rules"}, deleted); diff != "" {
+		t.Errorf("Reconcile(...): -want deleted, +got deleted:\n%s", diff)
+	}
+}
+
+func TestReconcileLegacyFinalizer(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		finalizers []string
+		want       []string
+	}{
+		"LegacyFinalizer": {
+			reason:     "The finalizer of earlier versions should be removed.",
+			finalizers: []string{"example.org/other", legacyFinalizer},
+			want:       []string{"example.org/other"},
+		},
+		"NoFinalizer": {
+			reason: "No finalizer should be added.",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					pr := newPrometheusRule()
+					pr.SetFinalizers(tc.finalizers)
+					pr.DeepCopyInto(obj.(*unstructured.Unstructured))
+					return nil
+				},
+				MockList: test.NewMockListFn(nil),
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					got = obj.GetFinalizers()
+					return nil
+				},
+			}
+
+			_, err := reconciler(t, kube).Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "api"}})
+			if err != nil {
+				t.Fatalf("\n%s\nReconcile(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want finalizers, +got finalizers:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
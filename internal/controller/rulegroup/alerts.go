@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/rulegroups"
+)
+
+const (
+	alertStateFiring  = "firing"
+	alertStatePending = "pending"
+
+	ruleTypeAlerting = "alerting"
+
+	// maxAlertSamples bounds the number of alerts listed per alerting rule.
+	maxAlertSamples = 5
+)
+
+// observeAlerts records the pending and firing alerts the rules API reports
+// for every alerting rule of the rule group in the status. Unlike the alerts
+// API, the rules API tells which rule of which group an alert belongs to, so
+// alerting rules of other groups with the same name are not counted.
+func observeAlerts(obs *v1alpha1.RuleGroupObservation, rules []rulegroups.RuleHealth) {
+	for _, r := range rules {
+		if r.Type != ruleTypeAlerting {
+			continue
+		}
+
+		ar := v1alpha1.AlertingRuleObservation{Name: r.Name}
+		alerts := r.Alerts
+		sortAlerts(alerts)
+		for _, a := range alerts {
+			switch a.State {
+			case alertStateFiring:
+				ar.Firing++
+			case alertStatePending:
+				ar.Pending++
+			default:
+				continue
+			}
+			if len(ar.Samples) < maxAlertSamples {
+				ar.Samples = append(ar.Samples, v1alpha1.AlertSample{State: a.State, Labels: a.Labels})
+			}
+		}
+		obs.FiringAlerts += ar.Firing
+		obs.PendingAlerts += ar.Pending
+		obs.Alerts = append(obs.Alerts, ar)
+	}
+}
+
+// sortAlerts sorts firing alerts before pending ones and both by their labels
+// so that the samples in the status do not change between observations.
+func sortAlerts(alerts []rulegroups.Alert) {
+	sort.SliceStable(alerts, func(i, j int) bool {
+		if alerts[i].State != alerts[j].State {
+			return alerts[i].State == alertStateFiring
+		}
+		return labelString(alerts[i].Labels) < labelString(alerts[j].Labels)
+	})
+}
+
+func labelString(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, k := range sortedKeys(labels) {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	return strings.Join(pairs, ",")
+}
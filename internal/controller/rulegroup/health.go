@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+)
+
+const (
+	healthErr = "err"
+
+	statusError = "error"
+)
+
+// observeHealth records the evaluation health and the alerts the ruler
+// reports for the rule group in the status and returns the Ready condition of
+// the RuleGroup. The rule group is only available if none of its rules failed
+// to evaluate. A failure to query the rules API is recorded in the status but
+// does not fail the observation of the rule group itself.
+func (c *external) observeHealth(ctx context.Context, cr *v1alpha1.RuleGroup, namespace, group string) xpv1.Condition {
+	obs := &cr.Status.AtProvider
+	obs.LastEvaluation = nil
+	obs.EvaluationTime = ""
+	obs.Rules = nil
+	obs.FiringAlerts = 0
+	obs.PendingAlerts = 0
+	obs.Alerts = nil
+
+	res, err := c.service.GetRuleGroupHealth(ctx, namespace, group)
+	if err != nil {
+		obs.Status = statusError
+		obs.ErrorType = ""
+		obs.Error = err.Error()
+		return xpv1.Available()
+	}
+
+	obs.Status = res.Status
+	obs.ErrorType = res.ErrorType
+	obs.Error = res.Error
+
+	// The ruler has not loaded the rule group yet.
+	if len(res.Data.Groups) == 0 {
+		return xpv1.Available()
+	}
+
+	g := res.Data.Groups[0]
+	obs.LastEvaluation = evaluationTimestamp(g.LastEvaluation)
+	obs.EvaluationTime = evaluationDuration(g.EvaluationTime)
+	observeAlerts(obs, g.Rules)
+
+	cond := xpv1.Available()
+	for _, r := range g.Rules {
+		obs.Rules = append(obs.Rules, v1alpha1.RuleObservation{
+			Name:           r.Name,
+			Type:           r.Type,
+			Health:         r.Health,
+			LastError:      r.LastError,
+			LastEvaluation: evaluationTimestamp(r.LastEvaluation),
+			EvaluationTime: evaluationDuration(r.EvaluationTime),
+		})
+
+		// Report the first failing rule only, the others are in the status.
+		if r.Health == healthErr && cond.Reason == xpv1.ReasonAvailable {
+			cond = xpv1.Unavailable().WithMessage(fmt.Sprintf("rule %q failed to evaluate: %s", r.Name, r.LastError))
+		}
+	}
+
+	return cond
+}
+
+// evaluationTimestamp returns nil for rules that were never evaluated.
+func evaluationTimestamp(t time.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	mt := metav1.NewTime(t)
+	return &mt
+}
+
+// evaluationDuration formats a duration in seconds as reported by the rules
+// API.
+func evaluationDuration(seconds float64) string {
+	if seconds == 0 {
+		return ""
+	}
+	return time.Duration(seconds * float64(time.Second)).String()
+}
@@ -18,19 +18,17 @@ package rulegroup
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
-	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	"github.com/prometheus/common/model"
-	"github.com/prometheus/prometheus/model/rulefmt"
-	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/cortexproject/cortex-tools/pkg/rules/rwrulefmt"
-	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -52,6 +50,17 @@ const (
 	errTrackPCUsage      = "cannot track ProviderConfig usage"
 	errGetPC             = "cannot get ProviderConfig"
 	errGetCreds          = "cannot get credentials"
+	errGenerateRuleGroup = "cannot generate rule group from spec"
+	errEmptyExternalName = "external name is not set"
+	errValidateRules     = "invalid rules"
+	errValidateGroup     = "invalid rule group"
+	errIndexRulesFrom    = "cannot index RuleGroups by rulesFrom"
+	errIndexTemplateRef  = "cannot index RuleGroups by templateRef"
+
+	errFmtInvalidExternalName = "external name %q is not of the form <namespace>/<group>"
+	errFmtDeletePrevious      = "cannot delete rule group from previous namespace %q"
+
+	reasonMovedRuleGroup event.Reason = "MovedRuleGroup"
 
 	errNewClient = "cannot create new Service"
 )
@@ -65,17 +74,30 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.RuleGroup{}, configMapIndexKey, configMapRefs); err != nil {
+		return errors.Wrap(err, errIndexRulesFrom)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.RuleGroup{}, secretIndexKey, secretRefs); err != nil {
+		return errors.Wrap(err, errIndexRulesFrom)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.RuleGroup{}, templateIndexKey, templateRefs); err != nil {
+		return errors.Wrap(err, errIndexTemplateRef)
+	}
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.RuleGroupGroupVersionKind),
 		managed.WithExternalConnecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			recorder:     recorder,
 			newServiceFn: newRuleGroupClient}),
 		// newServiceFn: xpClient.NewClient}),
 		// managed.NewNameAsExternalName(c)
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -83,6 +105,9 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		// WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.RuleGroup{}).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(enqueueRuleGroupsFor(mgr.GetClient(), configMapIndexKey))).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(enqueueRuleGroupsFor(mgr.GetClient(), secretIndexKey))).
+		Watches(&source.Kind{Type: &v1alpha1.RuleTemplate{}}, handler.EnqueueRequestsFromMapFunc(enqueueRuleGroupsFor(mgr.GetClient(), templateIndexKey))).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
@@ -91,6 +116,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
+	recorder     event.Recorder
 	newServiceFn func(config xpClient.Config) rulegroups.RuleGroupClient
 }
 
@@ -123,14 +149,23 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: c.newServiceFn(*config)}, nil
+	return &external{kube: c.kube, service: c.newServiceFn(*config), recorder: c.recorder, backend: config.Backend()}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
+	// Reads the ConfigMaps and Secrets rules are sourced from
+	kube client.Reader
+
 	// A 'client' used to connect to the external resource API
 	service rulegroups.RuleGroupClient
+
+	recorder event.Recorder
+
+	// The backend of the ProviderConfig, which selects the query language
+	// rules are validated with
+	backend string
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -139,7 +174,14 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotRuleGroup)
 	}
 
-	observedRuleGroup, err := c.service.GetRuleGroup(ctx, cr.Spec.ForProvider.Namespace, meta.GetExternalName(cr))
+	adopted, group, err := parseExternalName(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	namespace := cr.Spec.ForProvider.Namespace
+
+	observedRuleGroup, err := c.service.GetRuleGroup(ctx, namespace, group)
 	if err != nil {
 		switch {
 		case isErrRuleGroupNotFound(err):
@@ -155,7 +197,42 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}, nil
 	}
 
-	cr.Status.SetConditions(xpv1.Available())
+	rules, err := resolveRules(ctx, c.kube, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	desiredRuleGroup, err := rulegroups.NewRuleGroup(group, cr.Spec.ForProvider.RuleGroupSettings, rules)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGenerateRuleGroup)
+	}
+
+	drift := rulegroups.Drift(desiredRuleGroup, observedRuleGroup)
+
+	// The rule group was moved to the namespace of the spec, but it may still
+	// exist in the namespace it was applied to before.
+	previous, err := c.observePreviousNamespace(ctx, cr, adopted, group)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if previous != "" {
+		drift = append(drift, v1alpha1.RuleGroupDrift{Field: "namespace", Desired: namespace, Observed: previous})
+	} else {
+		cr.Status.AtProvider.Namespace = namespace
+	}
+
+	// Once an adopted rule group was moved, its external name is derived from
+	// the namespace of the spec. It is late initialized, as only the status is
+	// persisted after an update.
+	lateInitialized := false
+	if adopted != "" && adopted != namespace && previous == "" {
+		meta.SetExternalName(cr, namespace+"/"+group)
+		lateInitialized = true
+	}
+
+	cr.Status.AtProvider.Drift = drift
+
+	cr.Status.SetConditions(c.observeHealth(ctx, cr, namespace, group))
 
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
@@ -166,7 +243,12 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
-		ResourceUpToDate: isUpToDate(cr, observedRuleGroup),
+		ResourceUpToDate: len(drift) == 0,
+
+		ResourceLateInitialized: lateInitialized,
+
+		// Diff is logged by the managed resource reconciler at debug level.
+		Diff: rulegroups.Diff(desiredRuleGroup, observedRuleGroup),
 
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
@@ -180,39 +262,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotRuleGroup)
 	}
 
-	rns := []rulefmt.RuleNode{}
-
-	// iterate through group rules
-	for _, rule := range cr.Spec.ForProvider.Rules {
-		rn, err := generateRuleNode(rule)
-		if err != nil {
-			return managed.ExternalCreation{}, err
-		}
-
-		rns = append(rns, *rn)
-	}
-
-	var interval model.Duration
-	var err error
-
-	if cr.Spec.ForProvider.Interval != nil {
-		interval, err = model.ParseDuration(*cr.Spec.ForProvider.Interval)
-		if err != nil {
-			return managed.ExternalCreation{}, err
-		}
-	}
-
-	rw := rwrulefmt.RuleGroup{
-		RuleGroup: rulefmt.RuleGroup{
-			Name:     cr.GetName(),
-			Interval: interval,
-			// Limit: cr.Spec.ForProvider.Limit,
-			Rules: rns,
-		},
-	}
-
-	err = c.service.CreateRuleGroup(ctx, cr.Spec.ForProvider.Namespace, rw)
-	if err != nil {
+	if err := c.apply(ctx, cr); err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
@@ -225,39 +275,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotRuleGroup)
 	}
 
-	rns := []rulefmt.RuleNode{}
-
-	// iterate through group rules
-	for _, rule := range cr.Spec.ForProvider.Rules {
-		rn, err := generateRuleNode(rule)
-		if err != nil {
-			return managed.ExternalUpdate{}, err
-		}
-
-		rns = append(rns, *rn)
-	}
-
-	var interval model.Duration
-	var err error
-
-	if cr.Spec.ForProvider.Interval != nil {
-		interval, err = model.ParseDuration(*cr.Spec.ForProvider.Interval)
-		if err != nil {
-			return managed.ExternalUpdate{}, err
-		}
-	}
-
-	rw := rwrulefmt.RuleGroup{
-		RuleGroup: rulefmt.RuleGroup{
-			Name:     cr.GetName(),
-			Interval: interval,
-			// Limit: cr.Spec.ForProvider.Limit,
-			Rules: rns,
-		},
-	}
-
-	err = c.service.CreateRuleGroup(ctx, cr.Spec.ForProvider.Namespace, rw)
-	if err != nil {
+	if err := c.apply(ctx, cr); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
@@ -274,108 +292,132 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotRuleGroup)
 	}
 
-	err := c.service.DeleteRuleGroup(ctx, cr.Spec.ForProvider.Namespace, meta.GetExternalName(cr))
+	adopted, group, err := parseExternalName(cr)
+	if err != nil {
+		return err
+	}
+
+	// Do not leave the rule group behind in the namespace it was applied to
+	// before if a move did not complete.
+	if previous := previousNamespace(cr, adopted); previous != "" {
+		err := c.service.DeleteRuleGroup(ctx, previous, group)
+		if resource.Ignore(isErrRuleGroupNotFound, err) != nil {
+			return errors.Wrapf(err, errFmtDeletePrevious, previous)
+		}
+	}
+
+	err = c.service.DeleteRuleGroup(ctx, cr.Spec.ForProvider.Namespace, group)
 
 	return errors.Wrap(err, "")
 }
 
-//gocyclo:ignore
-func isUpToDate(cr *v1alpha1.RuleGroup, observedRuleGroup *rwrulefmt.RuleGroup) bool {
-	if cr == nil || observedRuleGroup == nil {
-		return false
+// apply creates or replaces the rule group in the namespace of the spec and
+// removes it from the namespace it was applied to before, if any.
+func (c *external) apply(ctx context.Context, cr *v1alpha1.RuleGroup) error {
+	adopted, group, err := parseExternalName(cr)
+	if err != nil {
+		return err
 	}
 
-	var interval model.Duration
-	var err error
-
-	if cr.Spec.ForProvider.Interval != nil {
-		interval, err = model.ParseDuration(*cr.Spec.ForProvider.Interval)
-		if err != nil {
-			return false
-		}
+	rules, err := resolveRules(ctx, c.kube, cr)
+	if err != nil {
+		return err
 	}
 
-	if interval != observedRuleGroup.Interval {
-		return false
+	if err := rulegroups.ValidateBackendFields(c.backend, cr.Spec.ForProvider.RuleGroupSettings); err != nil {
+		return errors.Wrap(err, errValidateGroup)
 	}
 
-	var recordRule rulefmt.RuleNode
-	var alertRule rulefmt.RuleNode
+	// Do not push rules the ruler would fail to evaluate.
+	if err := rulegroups.ValidateRules(rules, rulegroups.ExprValidatorFor(c.backend)); err != nil {
+		return errors.Wrap(err, errValidateRules)
+	}
 
-	// cortex rules
-	for _, rule := range observedRuleGroup.Rules {
-		if !rule.Record.IsZero() {
-			recordRule = rule
-		}
-		if !rule.Alert.IsZero() {
-			alertRule = rule
-		}
+	rw, err := rulegroups.NewRuleGroup(group, cr.Spec.ForProvider.RuleGroupSettings, rules)
+	if err != nil {
+		return err
 	}
 
-	// iterate through kubernetes rules and compare
-	for _, rule := range cr.Spec.ForProvider.Rules {
-		rn, err := generateRuleNode(rule)
-		if err != nil {
-			return false
-		}
+	namespace := cr.Spec.ForProvider.Namespace
 
-		if !rn.Alert.IsZero() {
-			if !cmp.Equal(rn.Alert.Value, alertRule.Alert.Value) {
-				return false
-			}
-		}
+	err = c.service.CreateRuleGroup(ctx, namespace, *rw)
+	if err != nil {
+		return err
+	}
 
-		if !rn.Record.IsZero() {
-			if !cmp.Equal(rn.Record.Value, recordRule.Record.Value) {
-				return false
-			}
-		}
+	previous := previousNamespace(cr, adopted)
+	if previous == "" {
+		return nil
 	}
 
-	return true
-}
+	err = c.service.DeleteRuleGroup(ctx, previous, group)
+	switch {
+	case isErrRuleGroupNotFound(err):
+	case err != nil:
+		return errors.Wrapf(err, errFmtDeletePrevious, previous)
+	default:
+		c.recorder.Event(cr, event.Normal(reasonMovedRuleGroup, fmt.Sprintf("Moved rule group %q from namespace %q to %q", group, previous, namespace)))
+	}
 
-// generates a Cortex RuleNode from a Kubernetes RuleNode
-func generateRuleNode(specRuleNode v1alpha1.RuleNode) (*rulefmt.RuleNode, error) {
-	rn := rulefmt.RuleNode{}
+	// The external name of an adopted rule group is left as it is, Observe
+	// derives it from the namespace of the spec once the move completed.
+	cr.Status.AtProvider.Namespace = namespace
 
-	if specRuleNode.Record != nil {
-		yn := yaml.Node{}
-		err := yaml.Unmarshal([]byte(*specRuleNode.Record), &yn)
-		if err != nil {
-			return nil, err
-		}
-		// we are interested in the ScalarNode
-		rn.Record = *yn.Content[0]
-	}
-	if specRuleNode.Alert != nil {
-		yn := yaml.Node{}
-		err := yaml.Unmarshal([]byte(*specRuleNode.Alert), &yn)
-		if err != nil {
-			return nil, err
-		}
-		rn.Alert = *yn.Content[0]
+	return nil
+}
+
+// observePreviousNamespace returns the namespace the rule group was applied to
+// before if it still exists there.
+func (c *external) observePreviousNamespace(ctx context.Context, cr *v1alpha1.RuleGroup, adopted, group string) (string, error) {
+	previous := previousNamespace(cr, adopted)
+	if previous == "" {
+		return "", nil
 	}
-	yn := yaml.Node{}
-	err := yaml.Unmarshal([]byte(specRuleNode.Expr), &yn)
-	if err != nil {
-		return nil, err
+
+	_, err := c.service.GetRuleGroup(ctx, previous, group)
+	switch {
+	case isErrRuleGroupNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", err
 	}
-	rn.Expr = *yn.Content[0]
-	if specRuleNode.For != nil {
-		rn.For, err = model.ParseDuration(*specRuleNode.For)
-		if err != nil {
-			return nil, err
-		}
+
+	return previous, nil
+}
+
+// parseExternalName returns the name of the rule group a RuleGroup refers to.
+// The external name is either the plain name of the group or the composite
+// <namespace>/<group> which allows to adopt an existing rule group. For a
+// composite external name the namespace is returned as well.
+func parseExternalName(cr *v1alpha1.RuleGroup) (string, string, error) {
+	en := meta.GetExternalName(cr)
+	if en == "" {
+		return "", "", errors.New(errEmptyExternalName)
 	}
-	if len(specRuleNode.Labels) != 0 {
-		rn.Labels = specRuleNode.Labels
+
+	namespace, group, composite := strings.Cut(en, "/")
+	if !composite {
+		return "", en, nil
 	}
-	if len(specRuleNode.Annotations) != 0 {
-		rn.Annotations = specRuleNode.Annotations
+
+	if namespace == "" || group == "" {
+		return "", "", errors.Errorf(errFmtInvalidExternalName, en)
 	}
 
-	return &rn, nil
+	return namespace, group, nil
+}
+
+// previousNamespace returns the namespace the rule group was last applied to,
+// or was adopted from, if it differs from the namespace of the spec.
+func previousNamespace(cr *v1alpha1.RuleGroup, adopted string) string {
+	previous := cr.Status.AtProvider.Namespace
+	if previous == "" {
+		previous = adopted
+	}
+	if previous == cr.Spec.ForProvider.Namespace {
+		return ""
+	}
+	return previous
 }
 
 func isErrRuleGroupNotFound(err error) bool {
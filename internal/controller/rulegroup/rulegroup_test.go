@@ -19,12 +19,28 @@ package rulegroup
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/cortexproject/cortex-tools/pkg/rules/rwrulefmt"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
 	"github.com/swisscom/provider-cortex/internal/clients/rulegroups"
 )
 
@@ -36,6 +52,126 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
+type mockRuleGroupClient struct {
+	MockGetRuleGroup    func(ctx context.Context, namespace string, groupName string) (*rulegroups.RuleGroup, error)
+	MockCreateRuleGroup func(ctx context.Context, namespace string, rg rulegroups.RuleGroup) error
+	MockDeleteRuleGroup func(ctx context.Context, namespace string, groupName string) error
+	MockListRuleGroups  func(ctx context.Context, namespace string) ([]rulegroups.RuleGroup, error)
+
+	MockGetRuleGroupHealth func(ctx context.Context, namespace string, groupName string) (*rulegroups.RulesResponse, error)
+}
+
+func (m *mockRuleGroupClient) GetRuleGroup(ctx context.Context, namespace string, groupName string) (*rulegroups.RuleGroup, error) {
+	return m.MockGetRuleGroup(ctx, namespace, groupName)
+}
+
+func (m *mockRuleGroupClient) CreateRuleGroup(ctx context.Context, namespace string, rg rulegroups.RuleGroup) error {
+	return m.MockCreateRuleGroup(ctx, namespace, rg)
+}
+
+func (m *mockRuleGroupClient) DeleteRuleGroup(ctx context.Context, namespace string, groupName string) error {
+	return m.MockDeleteRuleGroup(ctx, namespace, groupName)
+}
+
+func (m *mockRuleGroupClient) ListRuleGroups(ctx context.Context, namespace string) ([]rulegroups.RuleGroup, error) {
+	return m.MockListRuleGroups(ctx, namespace)
+}
+
+func (m *mockRuleGroupClient) GetRuleGroupHealth(ctx context.Context, namespace string, groupName string) (*rulegroups.RulesResponse, error) {
+	if m.MockGetRuleGroupHealth == nil {
+		return &rulegroups.RulesResponse{Status: "success"}, nil
+	}
+	return m.MockGetRuleGroupHealth(ctx, namespace, groupName)
+}
+
+// reasonRecorder records the reasons of the events it is given.
+type reasonRecorder struct {
+	reasons []event.Reason
+}
+
+func (r *reasonRecorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *reasonRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+type ruleGroupModifier func(*v1alpha1.RuleGroup)
+
+func withRules(rules ...v1alpha1.RuleNode) ruleGroupModifier {
+	return func(cr *v1alpha1.RuleGroup) { cr.Spec.ForProvider.Rules = rules }
+}
+
+func withRulesFrom(sources ...v1alpha1.RulesSource) ruleGroupModifier {
+	return func(cr *v1alpha1.RuleGroup) { cr.Spec.ForProvider.RulesFrom = sources }
+}
+
+func withExternalName(name string) ruleGroupModifier {
+	return func(cr *v1alpha1.RuleGroup) { meta.SetExternalName(cr, name) }
+}
+
+func withAppliedNamespace(namespace string) ruleGroupModifier {
+	return func(cr *v1alpha1.RuleGroup) { cr.Status.AtProvider.Namespace = namespace }
+}
+
+func withQueryOffset(offset string) ruleGroupModifier {
+	return func(cr *v1alpha1.RuleGroup) { cr.Spec.ForProvider.QueryOffset = &offset }
+}
+
+func withDrift(drift ...v1alpha1.RuleGroupDrift) ruleGroupModifier {
+	return func(cr *v1alpha1.RuleGroup) { cr.Status.AtProvider.Drift = drift }
+}
+
+func ruleGroup(m ...ruleGroupModifier) *v1alpha1.RuleGroup {
+	cr := &v1alpha1.RuleGroup{}
+	cr.SetName("example")
+	meta.SetExternalName(cr, "example")
+	cr.Spec.ForProvider.Namespace = "default"
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func strPtr(s string) *string { return &s }
+
+func intPtr(i int) *int { return &i }
+
+func scalar(s string) yaml.Node {
+	return yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+func observedGroup(rules ...rulefmt.RuleNode) *rulegroups.RuleGroup {
+	rg := &rulegroups.RuleGroup{Name: "example"}
+	for _, rn := range rules {
+		rg.Rules = append(rg.Rules, rulegroups.RuleNode{RuleNode: rn})
+	}
+	return rg
+}
+
+var evaluated = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func rulesResponse(rules ...rulegroups.RuleHealth) *rulegroups.RulesResponse {
+	res := &rulegroups.RulesResponse{Status: "success"}
+	res.Data.Groups = []rulegroups.RuleGroupHealth{{
+		Name:           "example",
+		File:           "default",
+		Rules:          rules,
+		LastEvaluation: evaluated,
+		EvaluationTime: 0.002,
+	}}
+	return res
+}
+
+func alert(state string, labels ...string) rulegroups.Alert {
+	a := rulegroups.Alert{State: state, Labels: map[string]string{}}
+	for i := 0; i+1 < len(labels); i += 2 {
+		a.Labels[labels[i]] = labels[i+1]
+	}
+	return a
+}
+
+func conditionPtr(c xpv1.Condition) *xpv1.Condition { return &c }
+
 func TestObserve(t *testing.T) {
 	type fields struct {
 		service rulegroups.RuleGroupClient
@@ -47,29 +183,909 @@ func TestObserve(t *testing.T) {
 	}
 
 	type want struct {
-		o   managed.ExternalObservation
-		err error
+		o                managed.ExternalObservation
+		drift            []v1alpha1.RuleGroupDrift
+		appliedNamespace string
+		externalName     string
+		health           *v1alpha1.RuleGroupObservation
+		ready            *xpv1.Condition
+		err              error
 	}
 
+	errBoom := errors.New("boom")
+
 	cases := map[string]struct {
 		reason string
 		fields fields
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotFound": {
+			reason: "A rule group that does not exist should be reported as such.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return nil, errors.New(errRuleGroupNotFound)
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup()},
+			want: want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"GetError": {
+			reason: "Errors other than not found should be returned.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return nil, errBoom
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup()},
+			want: want{err: errBoom},
+		},
+		"CompositeExternalName": {
+			reason: "A composite external name should be used to look up an existing rule group.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, namespace, groupName string) (*rulegroups.RuleGroup, error) {
+					if namespace != "default" || groupName != "adopted" {
+						return nil, errors.New(errRuleGroupNotFound)
+					}
+					return &rulegroups.RuleGroup{Name: "adopted"}, nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withExternalName("default/adopted"))},
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"AdoptFromOtherNamespace": {
+			reason: "A rule group adopted from another namespace than the spec should not exist until it is moved.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, namespace, groupName string) (*rulegroups.RuleGroup, error) {
+					if namespace != "other" {
+						return nil, errors.New(errRuleGroupNotFound)
+					}
+					return &rulegroups.RuleGroup{Name: groupName}, nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withExternalName("other/adopted"))},
+			want: want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"MovePending": {
+			reason: "A rule group that still exists in the namespace it was applied to before should be moved.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, groupName string) (*rulegroups.RuleGroup, error) {
+					return &rulegroups.RuleGroup{Name: groupName}, nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withAppliedNamespace("old"))},
+			want: want{
+				o:                managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift:            []v1alpha1.RuleGroupDrift{{Field: "namespace", Desired: "default", Observed: "old"}},
+				appliedNamespace: "old",
+			},
+		},
+		"MoveCompleted": {
+			reason: "A rule group that is gone from the namespace it was applied to before should be up to date.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, namespace, groupName string) (*rulegroups.RuleGroup, error) {
+					if namespace != "default" {
+						return nil, errors.New(errRuleGroupNotFound)
+					}
+					return &rulegroups.RuleGroup{Name: groupName}, nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withAppliedNamespace("old"))},
+			want: want{
+				o:                managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				appliedNamespace: "default",
+			},
+		},
+		"MoveAdoptedCompleted": {
+			reason: "The external name of an adopted rule group that was moved should be late initialized to the namespace of the spec.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, namespace, groupName string) (*rulegroups.RuleGroup, error) {
+					if namespace != "default" {
+						return nil, errors.New(errRuleGroupNotFound)
+					}
+					return &rulegroups.RuleGroup{Name: groupName}, nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withExternalName("other/adopted"), withAppliedNamespace("default"))},
+			want: want{
+				o:                managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				appliedNamespace: "default",
+				externalName:     "default/adopted",
+			},
+		},
+		"UpToDate": {
+			reason: "A rule group matching the spec should be up to date.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(rulefmt.RuleNode{
+						Alert:  scalar("HighLatency"),
+						Expr:   scalar("latency > 1"),
+						For:    model.Duration(5 * 60 * 1e9),
+						Labels: map[string]string{"severity": "page"},
+					}), nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(v1alpha1.RuleNode{
+				Alert:  strPtr("HighLatency"),
+				Expr:   "latency > 1",
+				For:    strPtr("5m"),
+				Labels: map[string]string{"severity": "page"},
+			}))},
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"ExprChanged": {
+			reason: "A changed expression of any rule should be reported as drift.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(
+						rulefmt.RuleNode{Record: scalar("job:up:sum"), Expr: scalar("sum(up)")},
+						rulefmt.RuleNode{Record: scalar("job:down:sum"), Expr: scalar("sum(1 - up)")},
+					), nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(
+				v1alpha1.RuleNode{Record: strPtr("job:up:sum"), Expr: "sum by (job) (up)"},
+				v1alpha1.RuleNode{Record: strPtr("job:down:sum"), Expr: "sum(1 - up)"},
+			))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift: []v1alpha1.RuleGroupDrift{
+					{Field: "rules[0].expr", Desired: "sum by (job) (up)", Observed: "sum(up)"},
+				},
+			},
+		},
+		"RuleRemoved": {
+			reason: "A rule that is no longer desired should be reported as drift.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(
+						rulefmt.RuleNode{Record: scalar("job:up:sum"), Expr: scalar("sum(up)")},
+						rulefmt.RuleNode{Alert: scalar("Down"), Expr: scalar("up == 0")},
+					), nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(
+				v1alpha1.RuleNode{Record: strPtr("job:up:sum"), Expr: "sum(up)"},
+			))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift: []v1alpha1.RuleGroupDrift{
+					{Field: "rules[1]", Observed: "alert: Down"},
+				},
+			},
+		},
+		"LabelChanged": {
+			reason: "A changed label should be reported as drift.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(rulefmt.RuleNode{
+						Alert:  scalar("Down"),
+						Expr:   scalar("up == 0"),
+						Labels: map[string]string{"severity": "warning"},
+					}), nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(v1alpha1.RuleNode{
+				Alert:  strPtr("Down"),
+				Expr:   "up == 0",
+				Labels: map[string]string{"severity": "page"},
+			}))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift: []v1alpha1.RuleGroupDrift{
+					{Field: "rules[0].labels[severity]", Desired: "page", Observed: "warning"},
+				},
+			},
+		},
+		"LimitAndKeepFiringForChanged": {
+			reason: "A changed group limit or keepFiringFor should be reported as drift.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					rg := observedGroup(rulefmt.RuleNode{Alert: scalar("Down"), Expr: scalar("up == 0")})
+					rg.Limit = 10
+					return rg, nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(
+				withRules(v1alpha1.RuleNode{Alert: strPtr("Down"), Expr: "up == 0", KeepFiringFor: strPtr("10m")}),
+				func(cr *v1alpha1.RuleGroup) { cr.Spec.ForProvider.Limit = intPtr(20) },
+			)},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift: []v1alpha1.RuleGroupDrift{
+					{Field: "limit", Desired: "20", Observed: "10"},
+					{Field: "rules[0].keepFiringFor", Desired: "10m", Observed: "0s"},
+				},
+			},
+		},
+		"FederationChanged": {
+			reason: "Changed source tenants or remote write targets should be reported as drift.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					rg := observedGroup(rulefmt.RuleNode{Record: scalar("slo:sum"), Expr: scalar("sum(up)")})
+					rg.SourceTenants = []string{"team-a"}
+					return rg, nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(
+				withRules(v1alpha1.RuleNode{Record: strPtr("slo:sum"), Expr: "sum(up)"}),
+				func(cr *v1alpha1.RuleGroup) {
+					cr.Spec.ForProvider.SourceTenants = []string{"team-a", "team-b"}
+					cr.Spec.ForProvider.RemoteWrite = []v1alpha1.RemoteWriteConfig{{URL: "http://example.org/push"}}
+				},
+			)},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift: []v1alpha1.RuleGroupDrift{
+					{Field: "sourceTenants[1]", Desired: "team-b"},
+					{Field: "remoteWrite", Desired: "[{http://example.org/push}]", Observed: "[]"},
+				},
+			},
+		},
+		"RemoteWriteChanged": {
+			reason: "A changed remote write target should be reported as drift of its field.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					rg := observedGroup(rulefmt.RuleNode{Record: scalar("slo:sum"), Expr: scalar("sum(up)")})
+					rg.RWConfigs = []rwrulefmt.RemoteWriteConfig{{URL: "http://old.example.org/push"}}
+					return rg, nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(
+				withRules(v1alpha1.RuleNode{Record: strPtr("slo:sum"), Expr: "sum(up)"}),
+				func(cr *v1alpha1.RuleGroup) {
+					cr.Spec.ForProvider.RemoteWrite = []v1alpha1.RemoteWriteConfig{{URL: "http://example.org/push"}}
+				},
+			)},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift: []v1alpha1.RuleGroupDrift{{Field: "remoteWrite[0].url", Desired: "http://example.org/push", Observed: "http://old.example.org/push"}},
+			},
+		},
+		"MimirFieldsChanged": {
+			reason: "Changed Mimir only fields should be reported as drift under their spec names.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					rg := observedGroup(rulefmt.RuleNode{Record: scalar("slo:sum"), Expr: scalar("sum(up)")})
+					rg.AlignEvaluationTimeOnInterval = true
+					return rg, nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(
+				withRules(v1alpha1.RuleNode{Record: strPtr("slo:sum"), Expr: "sum(up)"}),
+				withQueryOffset("1m"),
+			)},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift: []v1alpha1.RuleGroupDrift{
+					{Field: "queryOffset", Desired: "1m", Observed: "0s"},
+					{Field: "alignEvaluationTimeOnInterval", Desired: "false", Observed: "true"},
+				},
+			},
+		},
+		"DriftCleared": {
+			reason: "The recorded drift should be cleared once the rule group is up to date.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(rulefmt.RuleNode{Alert: scalar("Down"), Expr: scalar("up == 0")}), nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(
+				withRules(v1alpha1.RuleNode{Alert: strPtr("Down"), Expr: "up == 0"}),
+				withDrift(v1alpha1.RuleGroupDrift{Field: "interval", Desired: "1m", Observed: "0s"}),
+			)},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Healthy": {
+			reason: "The evaluation health of every rule should be recorded and the rule group should be available.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(rulefmt.RuleNode{Alert: scalar("Down"), Expr: scalar("up == 0")}), nil
+				},
+				MockGetRuleGroupHealth: func(_ context.Context, namespace, group string) (*rulegroups.RulesResponse, error) {
+					if namespace != "default" || group != "example" {
+						return nil, errBoom
+					}
+					return rulesResponse(rulegroups.RuleHealth{Name: "Down", Type: "alerting", Health: "ok", LastEvaluation: evaluated, EvaluationTime: 0.0015}), nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(v1alpha1.RuleNode{Alert: strPtr("Down"), Expr: "up == 0"}))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				health: &v1alpha1.RuleGroupObservation{
+					Status:         "success",
+					LastEvaluation: &metav1.Time{Time: evaluated},
+					EvaluationTime: "2ms",
+					Rules: []v1alpha1.RuleObservation{{
+						Name:           "Down",
+						Type:           "alerting",
+						Health:         "ok",
+						LastEvaluation: &metav1.Time{Time: evaluated},
+						EvaluationTime: "1.5ms",
+					}},
+					Alerts: []v1alpha1.AlertingRuleObservation{{Name: "Down"}},
+				},
+				ready: conditionPtr(xpv1.Available()),
+			},
+		},
+		"EvaluationFailed": {
+			reason: "A rule group with a rule that fails to evaluate should not be available.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(rulefmt.RuleNode{Alert: scalar("Down"), Expr: scalar("up == 0")}), nil
+				},
+				MockGetRuleGroupHealth: func(_ context.Context, _, _ string) (*rulegroups.RulesResponse, error) {
+					return rulesResponse(rulegroups.RuleHealth{Name: "Down", Type: "alerting", Health: "err", LastError: "many-to-many matching not allowed"}), nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(v1alpha1.RuleNode{Alert: strPtr("Down"), Expr: "up == 0"}))},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				ready: conditionPtr(xpv1.Unavailable().WithMessage(`rule "Down" failed to evaluate: many-to-many matching not allowed`)),
+			},
+		},
+		"Alerts": {
+			reason: "Pending and firing alerts should be counted per alerting rule and in total.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(
+						rulefmt.RuleNode{Record: scalar("job:up:sum"), Expr: scalar("sum by (job) (up)")},
+						rulefmt.RuleNode{Alert: scalar("Down"), Expr: scalar("up == 0")},
+						rulefmt.RuleNode{Alert: scalar("Slow"), Expr: scalar("latency > 1")},
+					), nil
+				},
+				MockGetRuleGroupHealth: func(_ context.Context, _, _ string) (*rulegroups.RulesResponse, error) {
+					return rulesResponse(
+						rulegroups.RuleHealth{Name: "job:up:sum", Type: "recording", Health: "ok"},
+						rulegroups.RuleHealth{Name: "Down", Type: "alerting", Health: "ok", Alerts: []rulegroups.Alert{
+							alert("pending", "alertname", "Down", "instance", "c"),
+							alert("firing", "alertname", "Down", "instance", "b"),
+							alert("firing", "alertname", "Down", "instance", "a"),
+						}},
+						rulegroups.RuleHealth{Name: "Slow", Type: "alerting", Health: "ok"},
+					), nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(
+				v1alpha1.RuleNode{Record: strPtr("job:up:sum"), Expr: "sum by (job) (up)"},
+				v1alpha1.RuleNode{Alert: strPtr("Down"), Expr: "up == 0"},
+				v1alpha1.RuleNode{Alert: strPtr("Slow"), Expr: "latency > 1"},
+			))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				health: &v1alpha1.RuleGroupObservation{
+					Status:         "success",
+					LastEvaluation: &metav1.Time{Time: evaluated},
+					EvaluationTime: "2ms",
+					Rules: []v1alpha1.RuleObservation{
+						{Name: "job:up:sum", Type: "recording", Health: "ok"},
+						{Name: "Down", Type: "alerting", Health: "ok"},
+						{Name: "Slow", Type: "alerting", Health: "ok"},
+					},
+					FiringAlerts:  2,
+					PendingAlerts: 1,
+					Alerts: []v1alpha1.AlertingRuleObservation{
+						{
+							Name:    "Down",
+							Firing:  2,
+							Pending: 1,
+							Samples: []v1alpha1.AlertSample{
+								{State: "firing", Labels: map[string]string{"alertname": "Down", "instance": "a"}},
+								{State: "firing", Labels: map[string]string{"alertname": "Down", "instance": "b"}},
+								{State: "pending", Labels: map[string]string{"alertname": "Down", "instance": "c"}},
+							},
+						},
+						{Name: "Slow"},
+					},
+				},
+			},
+		},
+		"AlertSamplesBounded": {
+			reason: "At most five alerts should be listed per alerting rule.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(rulefmt.RuleNode{Alert: scalar("Down"), Expr: scalar("up == 0")}), nil
+				},
+				MockGetRuleGroupHealth: func(_ context.Context, _, _ string) (*rulegroups.RulesResponse, error) {
+					return rulesResponse(rulegroups.RuleHealth{Name: "Down", Type: "alerting", Health: "ok", Alerts: []rulegroups.Alert{
+						alert("firing", "alertname", "Down", "instance", "f"),
+						alert("firing", "alertname", "Down", "instance", "e"),
+						alert("firing", "alertname", "Down", "instance", "d"),
+						alert("firing", "alertname", "Down", "instance", "c"),
+						alert("firing", "alertname", "Down", "instance", "b"),
+						alert("firing", "alertname", "Down", "instance", "a"),
+					}}), nil
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(v1alpha1.RuleNode{Alert: strPtr("Down"), Expr: "up == 0"}))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				health: &v1alpha1.RuleGroupObservation{
+					Status:         "success",
+					LastEvaluation: &metav1.Time{Time: evaluated},
+					EvaluationTime: "2ms",
+					Rules:          []v1alpha1.RuleObservation{{Name: "Down", Type: "alerting", Health: "ok"}},
+					FiringAlerts:   6,
+					Alerts: []v1alpha1.AlertingRuleObservation{{
+						Name:   "Down",
+						Firing: 6,
+						Samples: []v1alpha1.AlertSample{
+							{State: "firing", Labels: map[string]string{"alertname": "Down", "instance": "a"}},
+							{State: "firing", Labels: map[string]string{"alertname": "Down", "instance": "b"}},
+							{State: "firing", Labels: map[string]string{"alertname": "Down", "instance": "c"}},
+							{State: "firing", Labels: map[string]string{"alertname": "Down", "instance": "d"}},
+							{State: "firing", Labels: map[string]string{"alertname": "Down", "instance": "e"}},
+						},
+					}},
+				},
+			},
+		},
+		"HealthError": {
+			reason: "A failure to query the rules API should be recorded without failing the observation.",
+			fields: fields{service: &mockRuleGroupClient{
+				MockGetRuleGroup: func(_ context.Context, _, _ string) (*rulegroups.RuleGroup, error) {
+					return observedGroup(rulefmt.RuleNode{Alert: scalar("Down"), Expr: scalar("up == 0")}), nil
+				},
+				MockGetRuleGroupHealth: func(_ context.Context, _, _ string) (*rulegroups.RulesResponse, error) {
+					return nil, errBoom
+				},
+			}},
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(v1alpha1.RuleNode{Alert: strPtr("Down"), Expr: "up == 0"}))},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				health: &v1alpha1.RuleGroupObservation{
+					Status: "error",
+					Error:  errBoom.Error(),
+				},
+				ready: conditionPtr(xpv1.Available()),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service}
+			e := external{service: tc.fields.service, recorder: event.NewNopRecorder()}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			cr, _ := tc.args.mg.(*v1alpha1.RuleGroup)
+			if diff := cmp.Diff(tc.want.drift, cr.Status.AtProvider.Drift); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want drift, +got drift:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.appliedNamespace != "" {
+				if diff := cmp.Diff(tc.want.appliedNamespace, cr.Status.AtProvider.Namespace); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want namespace, +got namespace:\n%s\n", tc.reason, diff)
+				}
+			}
+			if tc.want.externalName != "" {
+				if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+				}
+			}
+			if tc.want.health != nil {
+				if diff := cmp.Diff(*tc.want.health, cr.Status.AtProvider, cmpopts.IgnoreFields(v1alpha1.RuleGroupObservation{}, "Namespace", "Drift")); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want health, +got health:\n%s\n", tc.reason, diff)
+				}
+			}
+			if tc.want.ready != nil {
+				if diff := cmp.Diff(*tc.want.ready, cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want ready, +got ready:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		created      string
+		deleted      string
+		externalName string
+		events       []event.Reason
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		backend string
+		args    args
+		want    want
+	}{
+		"ExternalName": {
+			reason: "The rule group should be created with the external name rather than the object name.",
+			args:   args{ctx: context.Background(), mg: ruleGroup(withExternalName("custom"))},
+			want:   want{created: "default/custom", externalName: "custom"},
+		},
+		"CompositeExternalName": {
+			reason: "The rule group should be created with the group part of a composite external name.",
+			args:   args{ctx: context.Background(), mg: ruleGroup(withExternalName("default/custom"))},
+			want:   want{created: "default/custom", externalName: "default/custom"},
+		},
+		"InvalidExternalName": {
+			reason: "An incomplete composite external name should be rejected.",
+			args:   args{ctx: context.Background(), mg: ruleGroup(withExternalName("default/"))},
+			want:   want{externalName: "default/", err: errors.Errorf(errFmtInvalidExternalName, "default/")},
+		},
+		"InvalidRules": {
+			reason: "Rules with an invalid expression should not be pushed.",
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(
+				v1alpha1.RuleNode{Record: strPtr("job:up:sum"), Expr: "sum(up"},
+			))},
+			want: want{
+				externalName: "example",
+				err:          errors.Wrap(errors.New("rules[0].expr: 1:7: parse error: unclosed left parenthesis"), errValidateRules),
+			},
+		},
+		"LokiRules": {
+			reason:  "LogQL rules should be pushed to a loki backend without PromQL validation.",
+			backend: apisv1alpha1.BackendLoki,
+			args: args{ctx: context.Background(), mg: ruleGroup(withRules(
+				v1alpha1.RuleNode{Record: strPtr("app:errors:rate5m"), Expr: `sum by (app) (rate({namespace="prod"} |= "error" [5m]))`},
+			))},
+			want: want{created: "default/example", externalName: "example"},
+		},
+		"MimirFields": {
+			reason:  "Mimir only fields should be pushed to a mimir backend.",
+			backend: apisv1alpha1.BackendMimir,
+			args:    args{ctx: context.Background(), mg: ruleGroup(withQueryOffset("1m"))},
+			want:    want{created: "default/example", externalName: "example"},
+		},
+		"MimirFieldsOnCortex": {
+			reason: "Mimir only fields should not be pushed to a cortex backend.",
+			args:   args{ctx: context.Background(), mg: ruleGroup(withQueryOffset("1m"))},
+			want: want{
+				externalName: "example",
+				err:          errors.Wrap(errors.New("queryOffset is only supported by the mimir backend, not by cortex"), errValidateGroup),
+			},
+		},
+		"MoveNamespace": {
+			reason: "The rule group should be removed from the namespace it was applied to before.",
+			args:   args{ctx: context.Background(), mg: ruleGroup(withAppliedNamespace("old"))},
+			want:   want{created: "default/example", deleted: "old/example", externalName: "example", events: []event.Reason{reasonMovedRuleGroup}},
+		},
+		"MoveAdopted": {
+			reason: "An adopted rule group should be moved to the namespace of the spec, leaving the external name to Observe.",
+			args:   args{ctx: context.Background(), mg: ruleGroup(withExternalName("other/adopted"))},
+			want:   want{created: "default/adopted", deleted: "other/adopted", externalName: "other/adopted", events: []event.Reason{reasonMovedRuleGroup}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created, deleted string
+			recorder := &reasonRecorder{}
+			e := external{
+				service: &mockRuleGroupClient{
+					MockCreateRuleGroup: func(_ context.Context, namespace string, rg rulegroups.RuleGroup) error {
+						created = namespace + "/" + rg.Name
+						return nil
+					},
+					MockDeleteRuleGroup: func(_ context.Context, namespace string, groupName string) error {
+						deleted = namespace + "/" + groupName
+						return nil
+					},
+				},
+				recorder: recorder,
+				backend:  tc.backend,
+			}
+			_, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.created, created); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want created, +got created:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want deleted, +got deleted:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.args.mg)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, recorder.reasons); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		created      string
+		deleted      string
+		events       []event.Reason
+		externalName string
+		o            managed.ExternalObservation
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.RuleGroup
+		want   want
+	}{
+		"MoveNamespace": {
+			reason: "A rule group whose namespace changed should be moved and observed as up to date afterwards.",
+			cr:     ruleGroup(withAppliedNamespace("old")),
+			want: want{
+				created:      "default/example",
+				deleted:      "old/example",
+				events:       []event.Reason{reasonMovedRuleGroup},
+				externalName: "example",
+				o:            managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"MoveAdopted": {
+			reason: "An adopted rule group whose namespace changed should be moved and its external name late initialized by the next observation.",
+			cr:     ruleGroup(withExternalName("other/adopted"), withAppliedNamespace("other")),
+			want: want{
+				created:      "default/adopted",
+				deleted:      "other/adopted",
+				events:       []event.Reason{reasonMovedRuleGroup},
+				externalName: "default/adopted",
+				o:            managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created, deleted string
+			recorder := &reasonRecorder{}
+			e := external{
+				service: &mockRuleGroupClient{
+					MockGetRuleGroup: func(_ context.Context, namespace, groupName string) (*rulegroups.RuleGroup, error) {
+						if namespace+"/"+groupName != created {
+							return nil, errors.New(errRuleGroupNotFound)
+						}
+						return &rulegroups.RuleGroup{Name: groupName}, nil
+					},
+					MockCreateRuleGroup: func(_ context.Context, namespace string, rg rulegroups.RuleGroup) error {
+						created = namespace + "/" + rg.Name
+						return nil
+					},
+					MockDeleteRuleGroup: func(_ context.Context, namespace string, groupName string) error {
+						deleted = namespace + "/" + groupName
+						return nil
+					},
+				},
+				recorder: recorder,
+			}
+
+			updated := tc.cr.DeepCopy()
+			if _, err := e.Update(context.Background(), updated); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.created, created); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want created, +got created:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want deleted, +got deleted:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, recorder.reasons); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+
+			// Only the status is persisted after an update.
+			persisted := tc.cr.DeepCopy()
+			persisted.Status = updated.Status
+			o, err := e.Observe(context.Background(), persisted)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.o, o, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(persisted)); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResolveRules(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.ConfigMap:
+				if key.Namespace != "monitoring" || key.Name != "rules" {
+					return errBoom
+				}
+				o.Data = map[string]string{
+					"group.yaml": "name: node\ninterval: 1m\nrules:\n- alert: Down\n  expr: up == 0\n  for: 5m\n  labels:\n    severity: critical\n",
+					"list.yaml":  "- record: job:up:sum\n  expr: sum by (job) (up)\n",
+					"file.yaml":  "groups: []\n",
+				}
+			case *corev1.Secret:
+				if key.Namespace != "monitoring" || key.Name != "rules" {
+					return errBoom
+				}
+				o.Data = map[string][]byte{
+					"list.yaml": []byte("- alert: Slow\n  expr: latency > 1\n  keep_firing_for: 10m\n"),
+				}
+			case *v1alpha1.RuleTemplate:
+				if key.Name != "availability" {
+					return errBoom
+				}
+				o.Spec.Parameters = []v1alpha1.RuleTemplateParameter{{Name: "job"}}
+				o.Spec.Rules = []v1alpha1.RuleNode{{Alert: strPtr("Down"), Expr: `up{job="${job}"} == 0`}}
+			}
+			return nil
+		},
+	}
+
+	configMapKey := func(key string) v1alpha1.RulesSource {
+		return v1alpha1.RulesSource{ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Name: "rules", Namespace: "monitoring", Key: key}}
+	}
+	secretKey := func(key string) v1alpha1.RulesSource {
+		return v1alpha1.RulesSource{SecretKeyRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "rules", Namespace: "monitoring"},
+			Key:             key,
+		}}
+	}
+
+	type want struct {
+		rules []v1alpha1.RuleNode
+		err   string
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.RuleGroup
+		want   want
+	}{
+		"InlineOnly": {
+			reason: "Inline rules should be returned as they are.",
+			cr:     ruleGroup(withRules(v1alpha1.RuleNode{Alert: strPtr("Down"), Expr: "up == 0"})),
+			want:   want{rules: []v1alpha1.RuleNode{{Alert: strPtr("Down"), Expr: "up == 0"}}},
+		},
+		"Merged": {
+			reason: "Sourced rules should be appended to the inline rules in the order of rulesFrom.",
+			cr: ruleGroup(
+				withRules(v1alpha1.RuleNode{Record: strPtr("inline"), Expr: "vector(1)"}),
+				withRulesFrom(configMapKey("group.yaml"), secretKey("list.yaml"), configMapKey("list.yaml")),
+			),
+			want: want{rules: []v1alpha1.RuleNode{
+				{Record: strPtr("inline"), Expr: "vector(1)"},
+				{Alert: strPtr("Down"), Expr: "up == 0", For: strPtr("5m"), Labels: map[string]string{"severity": "critical"}},
+				{Alert: strPtr("Slow"), Expr: "latency > 1", KeepFiringFor: strPtr("10m")},
+				{Record: strPtr("job:up:sum"), Expr: "sum by (job) (up)"},
+			}},
+		},
+		"Template": {
+			reason: "Rendered template rules should follow the inline rules and precede the rules of rulesFrom.",
+			cr: ruleGroup(
+				withRules(v1alpha1.RuleNode{Record: strPtr("inline"), Expr: "vector(1)"}),
+				withRulesFrom(configMapKey("list.yaml")),
+				func(cr *v1alpha1.RuleGroup) {
+					cr.Spec.ForProvider.TemplateRef = &v1alpha1.RuleTemplateReference{Name: "availability", Values: map[string]string{"job": "api"}}
+				},
+			),
+			want: want{rules: []v1alpha1.RuleNode{
+				{Record: strPtr("inline"), Expr: "vector(1)"},
+				{Alert: strPtr("Down"), Expr: `up{job="api"} == 0`},
+				{Record: strPtr("job:up:sum"), Expr: "sum by (job) (up)"},
+			}},
+		},
+		"MissingKey": {
+			reason: "A missing key should name the source.",
+			cr:     ruleGroup(withRulesFrom(configMapKey("group.yaml"), configMapKey("missing.yaml"))),
+			want:   want{err: `cannot get rules of rulesFrom[1]: key "missing.yaml" not found in ConfigMap monitoring/rules`},
+		},
+		"NotRules": {
+			reason: "A rule file with several groups should be rejected.",
+			cr:     ruleGroup(withRulesFrom(configMapKey("file.yaml"))),
+			want:   want{err: "cannot parse rules of rulesFrom[0]: neither a rule group nor a list of rules"},
+		},
+		"BothRefs": {
+			reason: "A source selecting both a ConfigMap and a Secret should be rejected.",
+			cr: ruleGroup(withRulesFrom(v1alpha1.RulesSource{
+				ConfigMapKeyRef: configMapKey("list.yaml").ConfigMapKeyRef,
+				SecretKeyRef:    secretKey("list.yaml").SecretKeyRef,
+			})),
+			want: want{err: "cannot get rules of rulesFrom[0]: exactly one of configMapKeyRef and secretKeyRef must be set"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveRules(context.Background(), kube, tc.cr)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.want.err, gotErr); diff != "" {
+				t.Errorf("\n%s\nresolveRules(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rules, got); diff != "" {
+				t.Errorf("\n%s\nresolveRules(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	tmpl := &v1alpha1.RuleTemplate{
+		Spec: v1alpha1.RuleTemplateSpec{
+			Parameters: []v1alpha1.RuleTemplateParameter{
+				{Name: "job", Type: v1alpha1.ParameterTypeString},
+				{Name: "threshold", Type: v1alpha1.ParameterTypeNumber},
+				{Name: "for", Type: v1alpha1.ParameterTypeDuration, Default: strPtr("5m")},
+				{Name: "severity", Type: v1alpha1.ParameterTypeString, Default: strPtr("warning")},
+			},
+			Rules: []v1alpha1.RuleNode{{
+				Alert:       strPtr("HighErrorRate_${job}"),
+				Expr:        `job:errors:ratio{job="${job}"} > ${threshold}`,
+				For:         strPtr("${for}"),
+				Labels:      map[string]string{"severity": "${severity}"},
+				Annotations: map[string]string{"summary": "{{ $labels.job }} error rate above ${threshold}"},
+			}},
+		},
+	}
+
+	type want struct {
+		rules []v1alpha1.RuleNode
+		err   string
+	}
+
+	cases := map[string]struct {
+		reason string
+		tmpl   *v1alpha1.RuleTemplate
+		values map[string]string
+		want   want
+	}{
+		"Rendered": {
+			reason: "Parameter references should be replaced by their values or defaults.",
+			tmpl:   tmpl,
+			values: map[string]string{"job": "api", "threshold": "0.05", "severity": "critical"},
+			want: want{rules: []v1alpha1.RuleNode{{
+				Alert:       strPtr("HighErrorRate_api"),
+				Expr:        `job:errors:ratio{job="api"} > 0.05`,
+				For:         strPtr("5m"),
+				Labels:      map[string]string{"severity": "critical"},
+				Annotations: map[string]string{"summary": "{{ $labels.job }} error rate above 0.05"},
+			}}},
+		},
+		"InvalidValues": {
+			reason: "Unknown parameters, missing values and values of the wrong type should be reported together.",
+			tmpl:   tmpl,
+			values: map[string]string{"threshold": "high", "for": "5 minutes", "team": "a"},
+			want: want{err: `[unknown parameter "team", missing value for parameter "job", ` +
+				`value "high" of parameter "threshold" is not a valid number, value "5 minutes" of parameter "for" is not a valid duration]`},
+		},
+		"UndeclaredParameter": {
+			reason: "A reference to an undeclared parameter should name the rule and field.",
+			tmpl: &v1alpha1.RuleTemplate{Spec: v1alpha1.RuleTemplateSpec{
+				Rules: []v1alpha1.RuleNode{{Record: strPtr("job:up:sum"), Expr: "sum(up)", Labels: map[string]string{"team": "${team}"}}},
+			}},
+			want: want{err: `rules[0].labels[team]: undeclared parameter "team"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := renderTemplate(tc.tmpl, tc.values)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.want.err, gotErr); diff != "" {
+				t.Errorf("\n%s\nrenderTemplate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rules, got); diff != "" {
+				t.Errorf("\n%s\nrenderTemplate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
 		})
 	}
 }
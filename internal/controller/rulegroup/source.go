@@ -0,0 +1,230 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroup
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+)
+
+const (
+	// Index RuleGroups by the <namespace>/<name> of the ConfigMaps, Secrets
+	// and RuleTemplates they source rules from.
+	configMapIndexKey = "spec.forProvider.rulesFrom.configMapKeyRef"
+	secretIndexKey    = "spec.forProvider.rulesFrom.secretKeyRef"
+	templateIndexKey  = "spec.forProvider.templateRef"
+
+	errInvalidRulesSource = "exactly one of configMapKeyRef and secretKeyRef must be set"
+	errNoRules            = "neither a rule group nor a list of rules"
+
+	errFmtGetConfigMap     = "cannot get ConfigMap %s/%s"
+	errFmtGetSecret        = "cannot get Secret %s/%s"
+	errFmtConfigMapKey     = "key %q not found in ConfigMap %s/%s"
+	errFmtSecretKey        = "key %q not found in Secret %s/%s"
+	errFmtRulesSource      = "cannot get rules of rulesFrom[%d]"
+	errFmtParseRulesSource = "cannot parse rules of rulesFrom[%d]"
+)
+
+// sourceRule is a rule in the Prometheus rule file format.
+type sourceRule struct {
+	Record        *string           `yaml:"record"`
+	Alert         *string           `yaml:"alert"`
+	Expr          string            `yaml:"expr"`
+	For           *string           `yaml:"for"`
+	KeepFiringFor *string           `yaml:"keep_firing_for"`
+	Labels        map[string]string `yaml:"labels"`
+	Annotations   map[string]string `yaml:"annotations"`
+}
+
+// resolveRules returns the inline rules of the RuleGroup followed by the
+// rendered rules of its template and the rules of every source in rulesFrom.
+func resolveRules(ctx context.Context, kube client.Reader, cr *v1alpha1.RuleGroup) ([]v1alpha1.RuleNode, error) {
+	rules := append([]v1alpha1.RuleNode{}, cr.Spec.ForProvider.Rules...)
+
+	if ref := cr.Spec.ForProvider.TemplateRef; ref != nil {
+		tmpl := &v1alpha1.RuleTemplate{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, tmpl); err != nil {
+			return nil, errors.Wrapf(err, errFmtGetTemplate, ref.Name)
+		}
+
+		rendered, err := renderTemplate(tmpl, ref.Values)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtRenderTemplate, ref.Name)
+		}
+
+		rules = append(rules, rendered...)
+	}
+
+	for i, src := range cr.Spec.ForProvider.RulesFrom {
+		data, err := getRulesSource(ctx, kube, src)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtRulesSource, i)
+		}
+
+		sourced, err := parseRules(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtParseRulesSource, i)
+		}
+
+		rules = append(rules, sourced...)
+	}
+
+	return rules, nil
+}
+
+// getRulesSource returns the value of the ConfigMap or Secret key a
+// RulesSource selects.
+func getRulesSource(ctx context.Context, kube client.Reader, src v1alpha1.RulesSource) ([]byte, error) {
+	switch {
+	case src.ConfigMapKeyRef != nil && src.SecretKeyRef == nil:
+		ref := src.ConfigMapKeyRef
+		cm := &corev1.ConfigMap{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return nil, errors.Wrapf(err, errFmtGetConfigMap, ref.Namespace, ref.Name)
+		}
+		if v, ok := cm.Data[ref.Key]; ok {
+			return []byte(v), nil
+		}
+		if v, ok := cm.BinaryData[ref.Key]; ok {
+			return v, nil
+		}
+		return nil, errors.Errorf(errFmtConfigMapKey, ref.Key, ref.Namespace, ref.Name)
+	case src.SecretKeyRef != nil && src.ConfigMapKeyRef == nil:
+		ref := src.SecretKeyRef
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, errFmtGetSecret, ref.Namespace, ref.Name)
+		}
+		if v, ok := s.Data[ref.Key]; ok {
+			return v, nil
+		}
+		return nil, errors.Errorf(errFmtSecretKey, ref.Key, ref.Namespace, ref.Name)
+	default:
+		return nil, errors.New(errInvalidRulesSource)
+	}
+}
+
+// parseRules parses either a single rule group or a list of rules in the
+// Prometheus rule file format.
+func parseRules(data []byte) ([]v1alpha1.RuleNode, error) {
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, errors.New(errNoRules)
+	}
+
+	var rules []sourceRule
+	switch n := doc.Content[0]; n.Kind {
+	case yaml.SequenceNode:
+		if err := n.Decode(&rules); err != nil {
+			return nil, err
+		}
+	case yaml.MappingNode:
+		group := struct {
+			Rules *[]sourceRule `yaml:"rules"`
+		}{}
+		if err := n.Decode(&group); err != nil {
+			return nil, err
+		}
+		if group.Rules == nil {
+			return nil, errors.New(errNoRules)
+		}
+		rules = *group.Rules
+	default:
+		return nil, errors.New(errNoRules)
+	}
+
+	nodes := make([]v1alpha1.RuleNode, 0, len(rules))
+	for _, r := range rules {
+		nodes = append(nodes, v1alpha1.RuleNode{
+			Record:        r.Record,
+			Alert:         r.Alert,
+			Expr:          r.Expr,
+			For:           r.For,
+			KeepFiringFor: r.KeepFiringFor,
+			Labels:        r.Labels,
+			Annotations:   r.Annotations,
+		})
+	}
+	return nodes, nil
+}
+
+// configMapRefs returns the ConfigMaps a RuleGroup sources rules from.
+func configMapRefs(o client.Object) []string {
+	cr, ok := o.(*v1alpha1.RuleGroup)
+	if !ok {
+		return nil
+	}
+	var refs []string
+	for _, src := range cr.Spec.ForProvider.RulesFrom {
+		if ref := src.ConfigMapKeyRef; ref != nil {
+			refs = append(refs, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}.String())
+		}
+	}
+	return refs
+}
+
+// secretRefs returns the Secrets a RuleGroup sources rules from.
+func secretRefs(o client.Object) []string {
+	cr, ok := o.(*v1alpha1.RuleGroup)
+	if !ok {
+		return nil
+	}
+	var refs []string
+	for _, src := range cr.Spec.ForProvider.RulesFrom {
+		if ref := src.SecretKeyRef; ref != nil {
+			refs = append(refs, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}.String())
+		}
+	}
+	return refs
+}
+
+// templateRefs returns the RuleTemplate a RuleGroup renders rules from.
+func templateRefs(o client.Object) []string {
+	cr, ok := o.(*v1alpha1.RuleGroup)
+	if !ok || cr.Spec.ForProvider.TemplateRef == nil {
+		return nil
+	}
+	return []string{types.NamespacedName{Name: cr.Spec.ForProvider.TemplateRef.Name}.String()}
+}
+
+// enqueueRuleGroupsFor enqueues every RuleGroup that sources rules from a
+// ConfigMap, Secret or RuleTemplate when it changes.
+func enqueueRuleGroupsFor(kube client.Reader, indexKey string) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		l := &v1alpha1.RuleGroupList{}
+		if err := kube.List(context.Background(), l, client.MatchingFields{indexKey: client.ObjectKeyFromObject(o).String()}); err != nil {
+			return nil
+		}
+		reqs := make([]reconcile.Request, 0, len(l.Items))
+		for _, rg := range l.Items {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: rg.GetName()}})
+		}
+		return reqs
+	}
+}
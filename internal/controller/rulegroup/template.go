@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulegroup
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+)
+
+const (
+	errFmtGetTemplate         = "cannot get RuleTemplate %s"
+	errFmtRenderTemplate      = "cannot render RuleTemplate %s"
+	errFmtUnknownParameter    = "unknown parameter %q"
+	errFmtMissingValue        = "missing value for parameter %q"
+	errFmtInvalidValue        = "value %q of parameter %q is not a valid %s"
+	errFmtUndeclaredParameter = "rules[%d].%s: undeclared parameter %q"
+)
+
+// parameterRef matches a ${name} reference to a template parameter.
+var parameterRef = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// renderTemplate replaces every parameter reference in the rules of a
+// RuleTemplate by its value. Values are checked against the type of their
+// parameter and fall back to the default of the parameter.
+func renderTemplate(tmpl *v1alpha1.RuleTemplate, values map[string]string) ([]v1alpha1.RuleNode, error) {
+	params, err := parameterValues(tmpl.Spec.Parameters, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	rules := make([]v1alpha1.RuleNode, 0, len(tmpl.Spec.Rules))
+	for i, rule := range tmpl.Spec.Rules {
+		r := renderer{params: params, index: i}
+		rules = append(rules, v1alpha1.RuleNode{
+			Record:        r.renderPtr("record", rule.Record),
+			Alert:         r.renderPtr("alert", rule.Alert),
+			Expr:          r.render("expr", rule.Expr),
+			For:           r.renderPtr("for", rule.For),
+			KeepFiringFor: r.renderPtr("keepFiringFor", rule.KeepFiringFor),
+			Labels:        r.renderMap("labels", rule.Labels),
+			Annotations:   r.renderMap("annotations", rule.Annotations),
+		})
+		errs = append(errs, r.errs...)
+	}
+	if len(errs) != 0 {
+		return nil, kerrors.NewAggregate(errs)
+	}
+
+	return rules, nil
+}
+
+// parameterValues returns the value of every parameter of a template.
+func parameterValues(params []v1alpha1.RuleTemplateParameter, values map[string]string) (map[string]string, error) {
+	declared := make(map[string]bool, len(params))
+	for _, p := range params {
+		declared[p.Name] = true
+	}
+
+	var errs []error
+	for _, name := range sortedKeys(values) {
+		if !declared[name] {
+			errs = append(errs, errors.Errorf(errFmtUnknownParameter, name))
+		}
+	}
+
+	resolved := make(map[string]string, len(params))
+	for _, p := range params {
+		v, ok := values[p.Name]
+		if !ok && p.Default != nil {
+			v, ok = *p.Default, true
+		}
+		if !ok {
+			errs = append(errs, errors.Errorf(errFmtMissingValue, p.Name))
+			continue
+		}
+		if err := checkParameterType(p.Type, v); err != nil {
+			errs = append(errs, errors.Errorf(errFmtInvalidValue, v, p.Name, p.Type))
+			continue
+		}
+		resolved[p.Name] = v
+	}
+
+	return resolved, kerrors.NewAggregate(errs)
+}
+
+// checkParameterType returns an error if value is not of the parameter type.
+func checkParameterType(typ, value string) error {
+	var err error
+	switch typ {
+	case v1alpha1.ParameterTypeInteger:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case v1alpha1.ParameterTypeNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case v1alpha1.ParameterTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	case v1alpha1.ParameterTypeDuration:
+		_, err = model.ParseDuration(value)
+	}
+	return err
+}
+
+// A renderer renders the fields of a single rule and collects the references
+// to undeclared parameters.
+type renderer struct {
+	params map[string]string
+	index  int
+	errs   []error
+}
+
+func (r *renderer) render(field, s string) string {
+	return parameterRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := parameterRef.FindStringSubmatch(ref)[1]
+		v, ok := r.params[name]
+		if !ok {
+			r.errs = append(r.errs, errors.Errorf(errFmtUndeclaredParameter, r.index, field, name))
+			return ref
+		}
+		return v
+	})
+}
+
+func (r *renderer) renderPtr(field string, s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := r.render(field, *s)
+	return &v
+}
+
+func (r *renderer) renderMap(field string, m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	rendered := make(map[string]string, len(m))
+	for _, k := range sortedKeys(m) {
+		rendered[k] = r.render(field+"["+k+"]", m[k])
+	}
+	return rendered
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,385 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulenamespace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
+	xpClient "github.com/swisscom/provider-cortex/internal/clients"
+	"github.com/swisscom/provider-cortex/internal/clients/rulegroups"
+	"github.com/swisscom/provider-cortex/internal/features"
+)
+
+const (
+	errNotRuleNamespace  = "managed resource is not a RuleNamespace custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errNewClient         = "cannot create new Service"
+	errEmptyExternalName = "external name is not set"
+	errListRuleGroups    = "cannot list rule groups"
+	errRuleGroupNotFound = "requested resource not found"
+	errFmtGenerateGroup  = "cannot generate rule group %q from spec"
+	errFmtValidateGroup  = "invalid rules in rule group %q"
+	errFmtCreateGroup    = "cannot create rule group %q"
+	errFmtDeleteGroup    = "cannot delete rule group %q"
+	errFmtDuplicateGroup = "rule group %q is listed more than once"
+
+	reasonPrunedRuleGroup event.Reason = "PrunedRuleGroup"
+)
+
+// Setup adds a controller that reconciles RuleNamespace managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RuleNamespaceGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RuleNamespaceGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			recorder:     recorder,
+			newServiceFn: newRuleGroupClient}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RuleNamespace{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	recorder     event.Recorder
+	newServiceFn func(config xpClient.Config) rulegroups.RuleGroupClient
+}
+
+func newRuleGroupClient(config xpClient.Config) rulegroups.RuleGroupClient {
+	return xpClient.NewClient(config)
+}
+
+// Connect produces an ExternalClient for the ProviderConfig of the
+// RuleNamespace.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RuleNamespace)
+	if !ok {
+		return nil, errors.New(errNotRuleNamespace)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	config, err := xpClient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: c.newServiceFn(*config), recorder: c.recorder, backend: config.Backend()}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes the
+// rule groups of a ruler namespace.
+type external struct {
+	service rulegroups.RuleGroupClient
+
+	recorder event.Recorder
+
+	// The backend of the ProviderConfig, which selects the query language
+	// rules are validated with
+	backend string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RuleNamespace)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRuleNamespace)
+	}
+
+	namespace := meta.GetExternalName(cr)
+	if namespace == "" {
+		return managed.ExternalObservation{}, errors.New(errEmptyExternalName)
+	}
+
+	observed, err := c.service.ListRuleGroups(ctx, namespace)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListRuleGroups)
+	}
+
+	// A namespace only exists for the ruler as long as it has rule groups.
+	if len(observed) == 0 {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	desired, err := generateRuleGroups(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	observedByName := make(map[string]*rulegroups.RuleGroup, len(observed))
+	names := make([]string, 0, len(observed))
+	for i := range observed {
+		observedByName[observed[i].Name] = &observed[i]
+		names = append(names, observed[i].Name)
+	}
+
+	// Without prune the RuleNamespace only owns the listed rule groups, and
+	// only those are deleted with it.
+	if !prune(cr) && !anyObserved(desired, observedByName) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	var drift []v1alpha1.RuleGroupDrift
+	var diff strings.Builder
+	for _, d := range desired {
+		field := fmt.Sprintf("groups[%s]", d.Name)
+		o, ok := observedByName[d.Name]
+		if !ok {
+			drift = append(drift, v1alpha1.RuleGroupDrift{Field: field, Desired: d.Name})
+			continue
+		}
+		for _, gd := range rulegroups.Drift(d, o) {
+			gd.Field = field + "." + gd.Field
+			drift = append(drift, gd)
+		}
+		if gd := rulegroups.Diff(d, o); gd != "" {
+			fmt.Fprintf(&diff, "%s:\n%s", field, gd)
+		}
+	}
+
+	unmanaged := unmanagedGroups(desired, names)
+	if prune(cr) {
+		for _, name := range unmanaged {
+			drift = append(drift, v1alpha1.RuleGroupDrift{Field: fmt.Sprintf("groups[%s]", name), Observed: name})
+		}
+	}
+
+	cr.Status.AtProvider.Groups = names
+	cr.Status.AtProvider.UnmanagedGroups = unmanaged
+	cr.Status.AtProvider.Drift = drift
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(drift) == 0,
+		Diff:             diff.String(),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RuleNamespace)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRuleNamespace)
+	}
+
+	return managed.ExternalCreation{}, c.apply(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RuleNamespace)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRuleNamespace)
+	}
+
+	return managed.ExternalUpdate{}, c.apply(ctx, cr)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RuleNamespace)
+	if !ok {
+		return errors.New(errNotRuleNamespace)
+	}
+
+	namespace := meta.GetExternalName(cr)
+	if namespace == "" {
+		return errors.New(errEmptyExternalName)
+	}
+
+	names := make([]string, 0, len(cr.Spec.ForProvider.Groups))
+	for _, g := range cr.Spec.ForProvider.Groups {
+		names = append(names, g.Name)
+	}
+
+	if prune(cr) {
+		observed, err := c.service.ListRuleGroups(ctx, namespace)
+		if err != nil {
+			return errors.Wrap(err, errListRuleGroups)
+		}
+		names = names[:0]
+		for _, o := range observed {
+			names = append(names, o.Name)
+		}
+	}
+
+	for _, name := range names {
+		err := c.service.DeleteRuleGroup(ctx, namespace, name)
+		if resource.Ignore(isErrRuleGroupNotFound, err) != nil {
+			return errors.Wrapf(err, errFmtDeleteGroup, name)
+		}
+	}
+
+	return nil
+}
+
+// apply creates or replaces every listed rule group and deletes the unlisted
+// ones if prune is enabled. All rule groups are validated before any of them
+// is pushed.
+func (c *external) apply(ctx context.Context, cr *v1alpha1.RuleNamespace) error {
+	namespace := meta.GetExternalName(cr)
+	if namespace == "" {
+		return errors.New(errEmptyExternalName)
+	}
+
+	for _, g := range cr.Spec.ForProvider.Groups {
+		if err := rulegroups.ValidateBackendFields(c.backend, g.RuleGroupSettings); err != nil {
+			return errors.Wrapf(err, errFmtValidateGroup, g.Name)
+		}
+		if err := rulegroups.ValidateRules(g.Rules, rulegroups.ExprValidatorFor(c.backend)); err != nil {
+			return errors.Wrapf(err, errFmtValidateGroup, g.Name)
+		}
+	}
+
+	desired, err := generateRuleGroups(cr)
+	if err != nil {
+		return err
+	}
+
+	for _, rg := range desired {
+		if err := c.service.CreateRuleGroup(ctx, namespace, *rg); err != nil {
+			return errors.Wrapf(err, errFmtCreateGroup, rg.Name)
+		}
+	}
+
+	if !prune(cr) {
+		return nil
+	}
+
+	observed, err := c.service.ListRuleGroups(ctx, namespace)
+	if err != nil {
+		return errors.Wrap(err, errListRuleGroups)
+	}
+	names := make([]string, 0, len(observed))
+	for _, o := range observed {
+		names = append(names, o.Name)
+	}
+
+	for _, name := range unmanagedGroups(desired, names) {
+		err := c.service.DeleteRuleGroup(ctx, namespace, name)
+		if resource.Ignore(isErrRuleGroupNotFound, err) != nil {
+			return errors.Wrapf(err, errFmtDeleteGroup, name)
+		}
+		c.recorder.Event(cr, event.Normal(reasonPrunedRuleGroup, fmt.Sprintf("Pruned rule group %q from namespace %q", name, namespace)))
+	}
+
+	return nil
+}
+
+// generateRuleGroups generates a Cortex RuleGroup for every listed group.
+func generateRuleGroups(cr *v1alpha1.RuleNamespace) ([]*rulegroups.RuleGroup, error) {
+	seen := map[string]bool{}
+	groups := make([]*rulegroups.RuleGroup, 0, len(cr.Spec.ForProvider.Groups))
+	for _, g := range cr.Spec.ForProvider.Groups {
+		if seen[g.Name] {
+			return nil, errors.Errorf(errFmtDuplicateGroup, g.Name)
+		}
+		seen[g.Name] = true
+
+		rg, err := rulegroups.NewRuleGroup(g.Name, g.RuleGroupSettings, g.Rules)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtGenerateGroup, g.Name)
+		}
+		groups = append(groups, rg)
+	}
+	return groups, nil
+}
+
+// anyObserved reports whether any of the desired rule groups was observed.
+func anyObserved(desired []*rulegroups.RuleGroup, observed map[string]*rulegroups.RuleGroup) bool {
+	for _, d := range desired {
+		if _, ok := observed[d.Name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unmanagedGroups returns the sorted names of the observed rule groups that
+// are not desired.
+func unmanagedGroups(desired []*rulegroups.RuleGroup, observed []string) []string {
+	want := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		want[d.Name] = true
+	}
+	var unmanaged []string
+	for _, name := range observed {
+		if !want[name] {
+			unmanaged = append(unmanaged, name)
+		}
+	}
+	sort.Strings(unmanaged)
+	return unmanaged
+}
+
+// prune defaults to false, so that the rule groups of RuleGroups in the same
+// namespace are only deleted if this was asked for.
+func prune(cr *v1alpha1.RuleNamespace) bool {
+	return cr.Spec.ForProvider.Prune != nil && *cr.Spec.ForProvider.Prune
+}
+
+func isErrRuleGroupNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), errRuleGroupNotFound)
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulenamespace
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/swisscom/provider-cortex/apis/rules/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/rulegroups"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type mockRuleGroupClient struct {
+	MockCreateRuleGroup func(ctx context.Context, namespace string, rg rulegroups.RuleGroup) error
+	MockDeleteRuleGroup func(ctx context.Context, namespace string, groupName string) error
+	MockListRuleGroups  func(ctx context.Context, namespace string) ([]rulegroups.RuleGroup, error)
+}
+
+func (m *mockRuleGroupClient) GetRuleGroup(_ context.Context, _ string, _ string) (*rulegroups.RuleGroup, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRuleGroupClient) CreateRuleGroup(ctx context.Context, namespace string, rg rulegroups.RuleGroup) error {
+	return m.MockCreateRuleGroup(ctx, namespace, rg)
+}
+
+func (m *mockRuleGroupClient) DeleteRuleGroup(ctx context.Context, namespace string, groupName string) error {
+	return m.MockDeleteRuleGroup(ctx, namespace, groupName)
+}
+
+func (m *mockRuleGroupClient) ListRuleGroups(ctx context.Context, namespace string) ([]rulegroups.RuleGroup, error) {
+	return m.MockListRuleGroups(ctx, namespace)
+}
+
+func (m *mockRuleGroupClient) GetRuleGroupHealth(_ context.Context, _ string, _ string) (*rulegroups.RulesResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+type ruleNamespaceModifier func(*v1alpha1.RuleNamespace)
+
+func withGroups(groups ...v1alpha1.RuleNamespaceGroup) ruleNamespaceModifier {
+	return func(cr *v1alpha1.RuleNamespace) { cr.Spec.ForProvider.Groups = groups }
+}
+
+func withPrune(prune bool) ruleNamespaceModifier {
+	return func(cr *v1alpha1.RuleNamespace) { cr.Spec.ForProvider.Prune = &prune }
+}
+
+func ruleNamespace(mods ...ruleNamespaceModifier) *v1alpha1.RuleNamespace {
+	cr := &v1alpha1.RuleNamespace{}
+	cr.SetName("example")
+	meta.SetExternalName(cr, "slo")
+	cr.Spec.ForProvider.Groups = []v1alpha1.RuleNamespaceGroup{group("availability", "up == 0")}
+	for _, m := range mods {
+		m(cr)
+	}
+	return cr
+}
+
+func group(name, expr string) v1alpha1.RuleNamespaceGroup {
+	alert := "Down"
+	return v1alpha1.RuleNamespaceGroup{Name: name, Rules: []v1alpha1.RuleNode{{Alert: &alert, Expr: expr}}}
+}
+
+// observed returns the rule groups the ruler would return for groups.
+func observed(t *testing.T, groups ...v1alpha1.RuleNamespaceGroup) []rulegroups.RuleGroup {
+	t.Helper()
+	rgs, err := generateRuleGroups(ruleNamespace(withGroups(groups...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := make([]rulegroups.RuleGroup, 0, len(rgs))
+	for _, rg := range rgs {
+		l = append(l, *rg)
+	}
+	return l
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o         managed.ExternalObservation
+		drift     []v1alpha1.RuleGroupDrift
+		unmanaged []string
+		err       error
+	}
+
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason   string
+		observed func(t *testing.T) ([]rulegroups.RuleGroup, error)
+		cr       *v1alpha1.RuleNamespace
+		want     want
+	}{
+		"NotFound": {
+			reason:   "A namespace without rule groups should not exist.",
+			observed: func(t *testing.T) ([]rulegroups.RuleGroup, error) { return nil, nil },
+			cr:       ruleNamespace(),
+			want:     want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"ListError": {
+			reason:   "An error listing the rule groups should be returned.",
+			observed: func(t *testing.T) ([]rulegroups.RuleGroup, error) { return nil, errBoom },
+			cr:       ruleNamespace(),
+			want:     want{err: errors.Wrap(errBoom, errListRuleGroups)},
+		},
+		"UpToDate": {
+			reason: "A namespace with exactly the listed rule groups should be up to date.",
+			observed: func(t *testing.T) ([]rulegroups.RuleGroup, error) {
+				return observed(t, group("availability", "up == 0")), nil
+			},
+			cr:   ruleNamespace(),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"DriftCleared": {
+			reason: "The recorded drift should be cleared once the namespace is up to date.",
+			observed: func(t *testing.T) ([]rulegroups.RuleGroup, error) {
+				return observed(t, group("availability", "up == 0")), nil
+			},
+			cr: func() *v1alpha1.RuleNamespace {
+				cr := ruleNamespace()
+				cr.Status.AtProvider.Drift = []v1alpha1.RuleGroupDrift{{Field: "groups[availability]", Desired: "availability"}}
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"GroupMissing": {
+			reason: "A listed rule group missing from the namespace should be reported as drift.",
+			observed: func(t *testing.T) ([]rulegroups.RuleGroup, error) {
+				return observed(t, group("availability", "up == 0")), nil
+			},
+			cr: ruleNamespace(withGroups(group("availability", "up == 0"), group("latency", "latency > 1"))),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift: []v1alpha1.RuleGroupDrift{{Field: "groups[latency]", Desired: "latency"}},
+			},
+		},
+		"GroupChanged": {
+			reason: "A changed rule should be reported with the path of its group.",
+			observed: func(t *testing.T) ([]rulegroups.RuleGroup, error) {
+				return observed(t, group("availability", "up == 1")), nil
+			},
+			cr: ruleNamespace(),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift: []v1alpha1.RuleGroupDrift{{Field: "groups[availability].rules[0].expr", Desired: "up == 0", Observed: "up == 1"}},
+			},
+		},
+		"UnmanagedPruned": {
+			reason: "An unlisted rule group should be reported and pruned.",
+			observed: func(t *testing.T) ([]rulegroups.RuleGroup, error) {
+				return observed(t, group("availability", "up == 0"), group("manual", "up == 0")), nil
+			},
+			cr: ruleNamespace(withPrune(true)),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				drift:     []v1alpha1.RuleGroupDrift{{Field: "groups[manual]", Observed: "manual"}},
+				unmanaged: []string{"manual"},
+			},
+		},
+		"UnmanagedKept": {
+			reason: "An unlisted rule group should only be reported if prune is disabled, as it is by default.",
+			observed: func(t *testing.T) ([]rulegroups.RuleGroup, error) {
+				return observed(t, group("availability", "up == 0"), group("manual", "up == 0")), nil
+			},
+			cr: ruleNamespace(),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				unmanaged: []string{"manual"},
+			},
+		},
+		"OnlyUnmanagedKept": {
+			reason: "A namespace with only unlisted rule groups should not exist if prune is disabled.",
+			observed: func(t *testing.T) ([]rulegroups.RuleGroup, error) {
+				return observed(t, group("manual", "up == 0")), nil
+			},
+			cr:   ruleNamespace(withPrune(false)),
+			want: want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				service: &mockRuleGroupClient{
+					MockListRuleGroups: func(_ context.Context, namespace string) ([]rulegroups.RuleGroup, error) {
+						if namespace != "slo" {
+							return nil, errBoom
+						}
+						return tc.observed(t)
+					},
+				},
+				recorder: event.NewNopRecorder(),
+			}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.drift, tc.cr.Status.AtProvider.Drift); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want drift, +got drift:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.unmanaged, tc.cr.Status.AtProvider.UnmanagedGroups); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want unmanaged, +got unmanaged:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		created []string
+		deleted []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.RuleNamespace
+		want   want
+	}{
+		"Prune": {
+			reason: "Listed rule groups should be pushed and unlisted ones deleted.",
+			cr:     ruleNamespace(withPrune(true), withGroups(group("availability", "up == 0"), group("latency", "latency > 1"))),
+			want:   want{created: []string{"availability", "latency"}, deleted: []string{"manual"}},
+		},
+		"NoPrune": {
+			reason: "Unlisted rule groups should be kept if prune is disabled, as it is by default.",
+			cr:     ruleNamespace(),
+			want:   want{created: []string{"availability"}},
+		},
+		"InvalidRules": {
+			reason: "No rule group should be pushed if any of them is invalid.",
+			cr:     ruleNamespace(withGroups(group("availability", "up == 0"), group("latency", "latency >"))),
+			want: want{err: errors.Wrapf(
+				errors.Wrap(errors.New("1:10: parse error: unexpected end of input"), "rules[0].expr"),
+				errFmtValidateGroup, "latency")},
+		},
+		"DuplicateGroup": {
+			reason: "A rule group listed twice should be rejected.",
+			cr:     ruleNamespace(withGroups(group("availability", "up == 0"), group("availability", "up == 1"))),
+			want:   want{err: errors.Errorf(errFmtDuplicateGroup, "availability")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created, deleted []string
+			e := external{
+				service: &mockRuleGroupClient{
+					MockCreateRuleGroup: func(_ context.Context, _ string, rg rulegroups.RuleGroup) error {
+						created = append(created, rg.Name)
+						return nil
+					},
+					MockDeleteRuleGroup: func(_ context.Context, _ string, groupName string) error {
+						deleted = append(deleted, groupName)
+						return nil
+					},
+					MockListRuleGroups: func(_ context.Context, _ string) ([]rulegroups.RuleGroup, error) {
+						return observed(t, group("availability", "up == 0"), group("latency", "latency > 1"), group("manual", "up == 0")), nil
+					},
+				},
+				recorder: event.NewNopRecorder(),
+			}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.created, created); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want created, +got created:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want deleted, +got deleted:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cr     resource.Managed
+		want   []string
+	}{
+		"Prune": {
+			reason: "All rule groups of the namespace should be deleted if prune is enabled.",
+			cr:     ruleNamespace(withPrune(true)),
+			want:   []string{"availability", "manual"},
+		},
+		"NoPrune": {
+			reason: "Only the listed rule groups should be deleted if prune is disabled, as it is by default.",
+			cr:     ruleNamespace(),
+			want:   []string{"availability"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			e := external{
+				service: &mockRuleGroupClient{
+					MockDeleteRuleGroup: func(_ context.Context, _ string, groupName string) error {
+						deleted = append(deleted, groupName)
+						return nil
+					},
+					MockListRuleGroups: func(_ context.Context, _ string) ([]rulegroups.RuleGroup, error) {
+						return observed(t, group("availability", "up == 0"), group("manual", "up == 0")), nil
+					},
+				},
+				recorder: event.NewNopRecorder(),
+			}
+			if err := e.Delete(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Delete(...): %v\n", tc.reason, err)
+			}
+			sort.Strings(deleted)
+			if diff := cmp.Diff(tc.want, deleted); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want deleted, +got deleted:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
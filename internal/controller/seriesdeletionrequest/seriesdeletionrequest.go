@@ -0,0 +1,356 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seriesdeletionrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/swisscom/provider-cortex/apis/tenants/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
+	xpClient "github.com/swisscom/provider-cortex/internal/clients"
+	"github.com/swisscom/provider-cortex/internal/clients/tenants"
+	"github.com/swisscom/provider-cortex/internal/features"
+)
+
+const (
+	// annotationSubmitted holds the parameters a request was submitted with.
+	// The request is never submitted again while it is set.
+	annotationSubmitted = "cortex.crossplane.io/submitted"
+
+	// maxClockSkew is how far the clock of the purger may be behind the one
+	// of the API server when looking for a submitted request.
+	maxClockSkew = 5 * time.Minute
+
+	errNotSeriesDeletionRequest = "managed resource is not a SeriesDeletionRequest custom resource"
+	errTrackPCUsage             = "cannot track ProviderConfig usage"
+	errGetPC                    = "cannot get ProviderConfig"
+	errNewClient                = "cannot create new Service"
+	errListRequests             = "cannot list series deletion requests"
+	errAddRequest               = "cannot submit series deletion request"
+	errCancelRequest            = "cannot cancel series deletion request"
+	errRequestNotFound          = "cannot find submitted series deletion request, remove the " + annotationSubmitted + " annotation to submit it again"
+	errParseSubmitted           = "cannot parse the " + annotationSubmitted + " annotation"
+	errImmutable                = "a submitted series deletion request cannot be changed, delete and recreate the SeriesDeletionRequest instead"
+)
+
+// Setup adds a controller that reconciles SeriesDeletionRequest managed
+// resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.SeriesDeletionRequestGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SeriesDeletionRequestGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: newSeriesDeletionClient}),
+		// The external name is the ID the purger assigns to the request.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.SeriesDeletionRequest{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(config xpClient.Config) tenants.SeriesDeletionClient
+}
+
+func newSeriesDeletionClient(config xpClient.Config) tenants.SeriesDeletionClient {
+	return xpClient.NewClient(config)
+}
+
+// Connect produces an ExternalClient for the ProviderConfig of the
+// SeriesDeletionRequest.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.SeriesDeletionRequest)
+	if !ok {
+		return nil, errors.New(errNotSeriesDeletionRequest)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	config, err := xpClient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: c.newServiceFn(*config)}, nil
+}
+
+// An ExternalClient submits, observes and cancels a series deletion request.
+type external struct {
+	service tenants.SeriesDeletionClient
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SeriesDeletionRequest)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSeriesDeletionRequest)
+	}
+
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	requests, err := c.service.ListDeleteRequests(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListRequests)
+	}
+
+	r := findRequest(requests, id)
+	if r == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = v1alpha1.SeriesDeletionRequestObservation{
+		RequestID:     r.RequestID,
+		Status:        r.Status,
+		CreatedAt:     timePtr(r.CreatedAt),
+		Start:         timePtr(r.StartTime),
+		End:           timePtr(r.EndTime),
+		CancelRefused: cr.Status.AtProvider.CancelRefused,
+	}
+
+	// Only a request that was not picked up by the purger yet and whose
+	// cancel period is not over can be cancelled.
+	if meta.WasDeleted(cr) && (r.Status != tenants.DeleteRequestStatusReceived || cr.Status.AtProvider.CancelRefused) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if r.Status == tenants.DeleteRequestStatusProcessed {
+		cr.Status.SetConditions(xpv1.Available())
+	} else {
+		cr.Status.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("series deletion request is %s", r.Status)))
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: matches(cr.Spec.ForProvider, *r),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SeriesDeletionRequest)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSeriesDeletionRequest)
+	}
+
+	submitted, err := getSubmitted(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errParseSubmitted)
+	}
+
+	// The purger does not return the ID of a new request, so it is looked up
+	// after submitting it. The parameters are recorded before, so that the
+	// request is not submitted again if it cannot be found right away.
+	requests, err := c.service.ListDeleteRequests(ctx)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errListRequests)
+	}
+
+	if submitted == nil {
+		// A request submitted before its parameters could be recorded is
+		// picked up rather than submitted again.
+		if r := findSubmittedRequest(requests, cr.Spec.ForProvider, cr); r != nil {
+			meta.SetExternalName(cr, r.RequestID)
+			return managed.ExternalCreation{}, nil
+		}
+
+		p := cr.Spec.ForProvider
+		if err := c.service.AddDeleteRequest(ctx, p.Matchers, metaTime(p.Start), metaTime(p.End)); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errAddRequest)
+		}
+		submitted = p.DeepCopy()
+		setSubmitted(cr, submitted)
+
+		requests, err = c.service.ListDeleteRequests(ctx)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errListRequests)
+		}
+	}
+
+	r := findSubmittedRequest(requests, *submitted, cr)
+	if r == nil {
+		return managed.ExternalCreation{}, errors.New(errRequestNotFound)
+	}
+
+	meta.SetExternalName(cr, r.RequestID)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(_ context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.SeriesDeletionRequest); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSeriesDeletionRequest)
+	}
+
+	return managed.ExternalUpdate{}, errors.New(errImmutable)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SeriesDeletionRequest)
+	if !ok {
+		return errors.New(errNotSeriesDeletionRequest)
+	}
+
+	err := c.service.CancelDeleteRequest(ctx, meta.GetExternalName(cr))
+	if tenants.IsNotCancellable(err) {
+		// The request is deleting the series already or is about to, so
+		// there is nothing left to cancel.
+		cr.Status.AtProvider.CancelRefused = true
+		return nil
+	}
+	return errors.Wrap(err, errCancelRequest)
+}
+
+// getSubmitted returns the parameters the request of cr was submitted with,
+// or nil if it was not submitted yet.
+func getSubmitted(cr *v1alpha1.SeriesDeletionRequest) (*v1alpha1.SeriesDeletionRequestParameters, error) {
+	a, ok := cr.GetAnnotations()[annotationSubmitted]
+	if !ok {
+		return nil, nil
+	}
+	p := &v1alpha1.SeriesDeletionRequestParameters{}
+	return p, json.Unmarshal([]byte(a), p)
+}
+
+// setSubmitted records the parameters the request of cr was submitted with.
+// The annotation is persisted by the managed reconciler whether or not
+// Create succeeds.
+func setSubmitted(cr *v1alpha1.SeriesDeletionRequest, p *v1alpha1.SeriesDeletionRequestParameters) {
+	b, _ := json.Marshal(p) //nolint:errchkjson // the parameters are always marshallable
+	meta.AddAnnotations(cr, map[string]string{annotationSubmitted: string(b)})
+}
+
+// findRequest returns the request with the ID id.
+func findRequest(requests []tenants.DeleteRequest, id string) *tenants.DeleteRequest {
+	for i := range requests {
+		if requests[i].RequestID == id {
+			return &requests[i]
+		}
+	}
+	return nil
+}
+
+// findSubmittedRequest returns the newest request matching p which was
+// submitted after cr was created. The clock of the purger may be up to
+// maxClockSkew behind the one of the API server.
+func findSubmittedRequest(requests []tenants.DeleteRequest, p v1alpha1.SeriesDeletionRequestParameters, cr *v1alpha1.SeriesDeletionRequest) *tenants.DeleteRequest {
+	created := model.TimeFromUnixNano(cr.GetCreationTimestamp().Add(-maxClockSkew).Truncate(time.Second).UnixNano())
+
+	var found *tenants.DeleteRequest
+	for i := range requests {
+		r := &requests[i]
+		if r.CreatedAt.Before(created) || !matches(p, *r) {
+			continue
+		}
+		if found == nil || r.CreatedAt.After(found.CreatedAt) {
+			found = r
+		}
+	}
+	return found
+}
+
+// matches returns true if r deletes the series p selects. An unset end is
+// not compared as the purger sets it to the time the request was submitted.
+func matches(p v1alpha1.SeriesDeletionRequestParameters, r tenants.DeleteRequest) bool {
+	if !equalSelectors(p.Matchers, r.Selectors) {
+		return false
+	}
+
+	start := model.Time(0)
+	if p.Start != nil {
+		start = model.TimeFromUnixNano(p.Start.UnixNano())
+	}
+	if r.StartTime != start {
+		return false
+	}
+
+	return p.End == nil || r.EndTime == model.TimeFromUnixNano(p.End.UnixNano())
+}
+
+func equalSelectors(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func metaTime(t *metav1.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	return &t.Time
+}
+
+func timePtr(t model.Time) *metav1.Time {
+	mt := metav1.NewTime(t.Time())
+	return &mt
+}
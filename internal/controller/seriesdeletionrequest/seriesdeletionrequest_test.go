@@ -0,0 +1,377 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seriesdeletionrequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/swisscom/provider-cortex/apis/tenants/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/tenants"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type mockSeriesDeletionClient struct {
+	MockAddDeleteRequest    func(ctx context.Context, matchers []string, start, end *time.Time) error
+	MockListDeleteRequests  func(ctx context.Context) ([]tenants.DeleteRequest, error)
+	MockCancelDeleteRequest func(ctx context.Context, id string) error
+}
+
+func (m *mockSeriesDeletionClient) AddDeleteRequest(ctx context.Context, matchers []string, start, end *time.Time) error {
+	return m.MockAddDeleteRequest(ctx, matchers, start, end)
+}
+
+func (m *mockSeriesDeletionClient) ListDeleteRequests(ctx context.Context) ([]tenants.DeleteRequest, error) {
+	return m.MockListDeleteRequests(ctx)
+}
+
+func (m *mockSeriesDeletionClient) CancelDeleteRequest(ctx context.Context, id string) error {
+	return m.MockCancelDeleteRequest(ctx, id)
+}
+
+var (
+	createdAt = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	start     = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	deletedAt = metav1.Now()
+	errBoom   = errors.New("boom")
+)
+
+type requestModifier func(*v1alpha1.SeriesDeletionRequest)
+
+func withExternalName(id string) requestModifier {
+	return func(cr *v1alpha1.SeriesDeletionRequest) { meta.SetExternalName(cr, id) }
+}
+
+func withMatchers(m ...string) requestModifier {
+	return func(cr *v1alpha1.SeriesDeletionRequest) { cr.Spec.ForProvider.Matchers = m }
+}
+
+func withSubmitted() requestModifier {
+	return func(cr *v1alpha1.SeriesDeletionRequest) { setSubmitted(cr, cr.Spec.ForProvider.DeepCopy()) }
+}
+
+func withCancelRefused() requestModifier {
+	return func(cr *v1alpha1.SeriesDeletionRequest) { cr.Status.AtProvider.CancelRefused = true }
+}
+
+func withDeletionTimestamp() requestModifier {
+	return func(cr *v1alpha1.SeriesDeletionRequest) { cr.SetDeletionTimestamp(&deletedAt) }
+}
+
+func seriesDeletionRequest(mods ...requestModifier) *v1alpha1.SeriesDeletionRequest {
+	cr := &v1alpha1.SeriesDeletionRequest{}
+	cr.SetName("remove-email-labels")
+	cr.SetCreationTimestamp(metav1.NewTime(createdAt))
+	cr.Spec.ForProvider = v1alpha1.SeriesDeletionRequestParameters{
+		Matchers: []string{`{job="app", email=~".+"}`},
+		Start:    &metav1.Time{Time: start},
+	}
+	for _, m := range mods {
+		m(cr)
+	}
+	return cr
+}
+
+// request returns a request the purger would return for the default spec.
+func request(id, status string, created time.Time) tenants.DeleteRequest {
+	return tenants.DeleteRequest{
+		RequestID: id,
+		StartTime: model.TimeFromUnixNano(start.UnixNano()),
+		EndTime:   model.TimeFromUnixNano(created.UnixNano()),
+		Selectors: []string{`{job="app", email=~".+"}`},
+		Status:    status,
+		CreatedAt: model.TimeFromUnixNano(created.UnixNano()),
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o         managed.ExternalObservation
+		status    string
+		condition *xpv1.Condition
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       *v1alpha1.SeriesDeletionRequest
+		requests []tenants.DeleteRequest
+		err      error
+		want     want
+	}{
+		"NotSubmitted": {
+			reason: "A request without an ID should not exist.",
+			cr:     seriesDeletionRequest(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"ListError": {
+			reason: "An error listing the requests should be returned.",
+			cr:     seriesDeletionRequest(withExternalName("abc")),
+			err:    errBoom,
+			want:   want{err: errors.Wrap(errBoom, errListRequests)},
+		},
+		"NotFound": {
+			reason:   "A request the purger does not know should not exist.",
+			cr:       seriesDeletionRequest(withExternalName("abc")),
+			requests: []tenants.DeleteRequest{request("def", tenants.DeleteRequestStatusReceived, createdAt)},
+			want:     want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"Received": {
+			reason:   "A received request should not be ready.",
+			cr:       seriesDeletionRequest(withExternalName("abc")),
+			requests: []tenants.DeleteRequest{request("abc", tenants.DeleteRequestStatusReceived, createdAt)},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				status: tenants.DeleteRequestStatusReceived,
+				condition: func() *xpv1.Condition {
+					c := xpv1.Unavailable().WithMessage("series deletion request is received")
+					return &c
+				}(),
+			},
+		},
+		"Processed": {
+			reason:   "A processed request should be ready.",
+			cr:       seriesDeletionRequest(withExternalName("abc")),
+			requests: []tenants.DeleteRequest{request("abc", tenants.DeleteRequestStatusProcessed, createdAt)},
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				status:    tenants.DeleteRequestStatusProcessed,
+				condition: func() *xpv1.Condition { c := xpv1.Available(); return &c }(),
+			},
+		},
+		"Changed": {
+			reason:   "A request whose matchers changed should not be up to date.",
+			cr:       seriesDeletionRequest(withExternalName("abc"), withMatchers(`{job="app", phone=~".+"}`)),
+			requests: []tenants.DeleteRequest{request("abc", tenants.DeleteRequestStatusProcessed, createdAt)},
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				status:    tenants.DeleteRequestStatusProcessed,
+				condition: func() *xpv1.Condition { c := xpv1.Available(); return &c }(),
+			},
+		},
+		"DeletedCancellable": {
+			reason:   "A received request should be cancelled on delete.",
+			cr:       seriesDeletionRequest(withExternalName("abc"), withDeletionTimestamp()),
+			requests: []tenants.DeleteRequest{request("abc", tenants.DeleteRequestStatusReceived, createdAt)},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				status: tenants.DeleteRequestStatusReceived,
+			},
+		},
+		"DeletedCancelRefused": {
+			reason:   "A received request the purger refused to cancel should be left alone on delete.",
+			cr:       seriesDeletionRequest(withExternalName("abc"), withDeletionTimestamp(), withCancelRefused()),
+			requests: []tenants.DeleteRequest{request("abc", tenants.DeleteRequestStatusReceived, createdAt.Add(-48*time.Hour))},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: false},
+				status: tenants.DeleteRequestStatusReceived,
+			},
+		},
+		"DeletedInProgress": {
+			reason:   "A request the purger picked up cannot be cancelled and should be left alone on delete.",
+			cr:       seriesDeletionRequest(withExternalName("abc"), withDeletionTimestamp()),
+			requests: []tenants.DeleteRequest{request("abc", tenants.DeleteRequestStatusDeleting, createdAt)},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: false},
+				status: tenants.DeleteRequestStatusDeleting,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				service: &mockSeriesDeletionClient{
+					MockListDeleteRequests: func(_ context.Context) ([]tenants.DeleteRequest, error) {
+						return tc.requests, tc.err
+					},
+				},
+			}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, tc.cr.Status.AtProvider.Status); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.condition != nil {
+				if diff := cmp.Diff(*tc.want.condition, tc.cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		externalName string
+		submitted    bool
+		annotated    bool
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.SeriesDeletionRequest
+		before []tenants.DeleteRequest
+		after  []tenants.DeleteRequest
+		want   want
+	}{
+		"Submitted": {
+			reason: "The ID of the submitted request should be recorded.",
+			cr:     seriesDeletionRequest(),
+			before: []tenants.DeleteRequest{request("old", tenants.DeleteRequestStatusProcessed, createdAt.Add(-time.Hour))},
+			after: []tenants.DeleteRequest{
+				request("old", tenants.DeleteRequestStatusProcessed, createdAt.Add(-time.Hour)),
+				request("new", tenants.DeleteRequestStatusReceived, createdAt.Add(time.Second)),
+			},
+			want: want{externalName: "new", submitted: true, annotated: true},
+		},
+		"ClockSkew": {
+			reason: "A request should be found if the clock of the purger is behind the one of the API server.",
+			cr:     seriesDeletionRequest(),
+			after:  []tenants.DeleteRequest{request("new", tenants.DeleteRequestStatusReceived, createdAt.Add(-time.Minute))},
+			want:   want{externalName: "new", submitted: true, annotated: true},
+		},
+		"AlreadySubmitted": {
+			reason: "A request submitted before its parameters could be recorded should not be submitted again.",
+			cr:     seriesDeletionRequest(),
+			before: []tenants.DeleteRequest{request("new", tenants.DeleteRequestStatusReceived, createdAt.Add(time.Second))},
+			want:   want{externalName: "new"},
+		},
+		"NotFound": {
+			reason: "An error should be returned and the submission recorded if the submitted request cannot be found.",
+			cr:     seriesDeletionRequest(),
+			want:   want{submitted: true, annotated: true, err: errors.New(errRequestNotFound)},
+		},
+		"RecordedNotFound": {
+			reason: "A request whose submission is recorded should not be submitted again, even if it cannot be found.",
+			cr:     seriesDeletionRequest(withSubmitted()),
+			want:   want{annotated: true, err: errors.New(errRequestNotFound)},
+		},
+		"RecordedChanged": {
+			reason: "A request should be found by the parameters it was submitted with, not the current ones.",
+			cr:     seriesDeletionRequest(withSubmitted(), withMatchers(`{job="app", phone=~".+"}`)),
+			before: []tenants.DeleteRequest{request("new", tenants.DeleteRequestStatusReceived, createdAt.Add(time.Second))},
+			want:   want{externalName: "new", annotated: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			submitted := false
+			e := external{
+				service: &mockSeriesDeletionClient{
+					MockAddDeleteRequest: func(_ context.Context, matchers []string, s, end *time.Time) error {
+						if len(matchers) != 1 || s == nil || !s.Equal(start) || end != nil {
+							return errors.New("unexpected request")
+						}
+						submitted = true
+						return nil
+					},
+					MockListDeleteRequests: func(_ context.Context) ([]tenants.DeleteRequest, error) {
+						if submitted {
+							return tc.after, nil
+						}
+						return tc.before, nil
+					},
+				},
+			}
+			cr := tc.cr
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.submitted, submitted); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want submitted, +got submitted:\n%s\n", tc.reason, diff)
+			}
+			if _, annotated := cr.GetAnnotations()[annotationSubmitted]; annotated != tc.want.annotated {
+				t.Errorf("\n%s\ne.Create(...): want annotated %t, got %t", tc.reason, tc.want.annotated, annotated)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		err     error
+		want    error
+		refused bool
+	}{
+		"Cancelled": {
+			reason: "The request should be cancelled.",
+		},
+		"CancelPeriodOver": {
+			reason:  "A request whose cancel period is over should be recorded as not cancellable instead of retrying.",
+			err:     errors.New("server returned HTTP status 400 Bad Request: deletion of request past the deadline of 24h0m0s since its creation is not allowed"),
+			refused: true,
+		},
+		"Error": {
+			reason: "An error cancelling the request should be returned.",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errCancelRequest),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				service: &mockSeriesDeletionClient{
+					MockCancelDeleteRequest: func(_ context.Context, id string) error {
+						if id != "abc" {
+							return errors.New("unexpected ID")
+						}
+						return tc.err
+					},
+				},
+			}
+			cr := seriesDeletionRequest(withExternalName("abc"))
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.refused, cr.Status.AtProvider.CancelRefused); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want cancelRefused, +got cancelRefused:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package silence
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
+	xpClient "github.com/swisscom/provider-cortex/internal/clients"
+	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
+	"github.com/swisscom/provider-cortex/internal/features"
+)
+
+const (
+	errNotSilence      = "managed resource is not a Silence custom resource"
+	errSilenceNotFound = "requested resource not found"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errNewClient       = "cannot create new Service"
+	errGetSilence      = "cannot get silence"
+	errListSilences    = "cannot list silences"
+	errCreateSilence   = "cannot create silence"
+	errUpdateSilence   = "cannot update silence"
+	errDeleteSilence   = "cannot expire silence"
+)
+
+// Setup adds a controller that reconciles Silence managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.SilenceGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SilenceGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: newSilenceClient}),
+		// The external name is the ID the Alertmanager assigns to the silence.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Silence{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(config xpClient.Config) alertmanager.SilenceClient
+}
+
+func newSilenceClient(config xpClient.Config) alertmanager.SilenceClient {
+	return xpClient.NewClient(config)
+}
+
+// Connect produces an ExternalClient for the ProviderConfig of the Silence.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Silence)
+	if !ok {
+		return nil, errors.New(errNotSilence)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	config, err := xpClient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: c.newServiceFn(*config), now: time.Now}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or expires a
+// silence.
+type external struct {
+	service alertmanager.SilenceClient
+	now     func() time.Time
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Silence)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSilence)
+	}
+
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	s, err := c.service.GetSilence(ctx, id)
+	if err != nil {
+		if isErrSilenceNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetSilence)
+	}
+
+	state := ""
+	if s.Status != nil {
+		state = s.Status.State
+	}
+
+	cr.Status.AtProvider = v1alpha1.SilenceObservation{
+		State:    state,
+		StartsAt: timePtr(s.StartsAt),
+		EndsAt:   timePtr(s.EndsAt),
+	}
+	if s.UpdatedAt != nil {
+		cr.Status.AtProvider.UpdatedAt = timePtr(*s.UpdatedAt)
+	}
+
+	if state == alertmanager.SilenceStateExpired {
+		// An expired silence cannot be expired again.
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+
+		// The silence was expired out of band before its end and has to be
+		// created again.
+		if c.now().Before(cr.Spec.ForProvider.EndsAt.Time) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+
+		cr.Status.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("silence expired at %s", s.EndsAt.UTC().Format(time.RFC3339))))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	switch state {
+	case alertmanager.SilenceStateActive:
+		cr.Status.SetConditions(xpv1.Available())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("silence starts at %s", s.StartsAt.UTC().Format(time.RFC3339))))
+	}
+
+	desired := generateSilence(cr, c.now())
+	upToDate, diff := isUpToDate(desired, s, startsInFuture(cr, c.now()))
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+		Diff:             diff,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Silence)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSilence)
+	}
+
+	desired := generateSilence(cr, c.now())
+
+	// The silence exists already if the Alertmanager replaced it by a new one
+	// during an update. Adopting it rather than creating another one lets the
+	// managed reconciler persist its ID with the external name.
+	id, err := c.findSilence(ctx, desired, startsInFuture(cr, c.now()))
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if id == "" {
+		id, err = c.service.CreateSilence(ctx, desired)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateSilence)
+		}
+	}
+
+	meta.SetExternalName(cr, id)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Silence)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSilence)
+	}
+
+	s := generateSilence(cr, c.now())
+	s.ID = meta.GetExternalName(cr)
+
+	// The Alertmanager replaces a silence whose matchers or start changed by
+	// a new one and expires the old one. The managed reconciler does not
+	// persist the external name after an update, so the new silence is
+	// adopted by Create once the old one is observed expired.
+	if _, err := c.service.CreateSilence(ctx, s); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSilence)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Silence)
+	if !ok {
+		return errors.New(errNotSilence)
+	}
+
+	err := c.service.DeleteSilence(ctx, meta.GetExternalName(cr))
+	if resource.Ignore(isErrSilenceNotFound, err) != nil {
+		return errors.Wrap(err, errDeleteSilence)
+	}
+
+	return nil
+}
+
+// findSilence returns the ID of a pending or active silence that matches the
+// desired one, or an empty ID if there is none.
+func (c *external) findSilence(ctx context.Context, desired alertmanager.Silence, compareStart bool) (string, error) {
+	silences, err := c.service.ListSilences(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, errListSilences)
+	}
+
+	for i := range silences {
+		s := &silences[i]
+		if s.Status != nil && s.Status.State == alertmanager.SilenceStateExpired {
+			continue
+		}
+		if upToDate, _ := isUpToDate(desired, s, compareStart); upToDate {
+			return s.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// generateSilence generates an Alertmanager silence from the spec of a
+// Silence. A silence without a start starts now.
+func generateSilence(cr *v1alpha1.Silence, now time.Time) alertmanager.Silence {
+	p := cr.Spec.ForProvider
+
+	s := alertmanager.Silence{
+		StartsAt:  now,
+		EndsAt:    p.EndsAt.Time,
+		CreatedBy: p.CreatedBy,
+		Comment:   p.Comment,
+		Matchers:  make([]alertmanager.Matcher, 0, len(p.Matchers)),
+	}
+	if p.StartsAt != nil {
+		s.StartsAt = p.StartsAt.Time
+	}
+	for _, m := range p.Matchers {
+		s.Matchers = append(s.Matchers, alertmanager.Matcher{
+			Name:    m.Name,
+			Value:   m.Value,
+			IsRegex: m.IsRegex,
+			IsEqual: m.IsEqual == nil || *m.IsEqual,
+		})
+	}
+	return s
+}
+
+// startsInFuture returns whether the Silence has a start that lies after now.
+func startsInFuture(cr *v1alpha1.Silence, now time.Time) bool {
+	return cr.Spec.ForProvider.StartsAt != nil && now.Before(cr.Spec.ForProvider.StartsAt.Time)
+}
+
+// silenceState is the comparable form of a silence. Times are compared to
+// the second as metav1.Time does not keep fractions of a second.
+type silenceState struct {
+	Matchers  []alertmanager.Matcher
+	StartsAt  time.Time
+	EndsAt    time.Time
+	CreatedBy string
+	Comment   string
+}
+
+func newSilenceState(s alertmanager.Silence, compareStart bool) silenceState {
+	st := silenceState{
+		Matchers:  append([]alertmanager.Matcher{}, s.Matchers...),
+		EndsAt:    s.EndsAt.Truncate(time.Second).UTC(),
+		CreatedBy: s.CreatedBy,
+		Comment:   s.Comment,
+	}
+	// The Alertmanager moves the start of a silence to the time it is
+	// created if it lies in the past.
+	if compareStart {
+		st.StartsAt = s.StartsAt.Truncate(time.Second).UTC()
+	}
+	sort.Slice(st.Matchers, func(i, j int) bool {
+		return matcherKey(st.Matchers[i]) < matcherKey(st.Matchers[j])
+	})
+	return st
+}
+
+func matcherKey(m alertmanager.Matcher) string {
+	return fmt.Sprintf("%s/%t/%t/%s", m.Name, m.IsEqual, m.IsRegex, m.Value)
+}
+
+// isUpToDate compares the desired and the observed silence. The start is
+// only compared if the silence has not started yet.
+func isUpToDate(desired alertmanager.Silence, observed *alertmanager.Silence, compareStart bool) (bool, string) {
+	diff := cmp.Diff(newSilenceState(desired, compareStart), newSilenceState(*observed, compareStart))
+	return diff == "", diff
+}
+
+func timePtr(t time.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	mt := metav1.NewTime(t)
+	return &mt
+}
+
+func isErrSilenceNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), errSilenceNotFound)
+}
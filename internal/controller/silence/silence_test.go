@@ -0,0 +1,432 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package silence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/swisscom/provider-cortex/apis/alerts/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/alertmanager"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type mockSilenceClient struct {
+	MockGetSilence    func(ctx context.Context, id string) (*alertmanager.Silence, error)
+	MockListSilences  func(ctx context.Context) ([]alertmanager.Silence, error)
+	MockCreateSilence func(ctx context.Context, s alertmanager.Silence) (string, error)
+	MockDeleteSilence func(ctx context.Context, id string) error
+}
+
+func (m *mockSilenceClient) GetSilence(ctx context.Context, id string) (*alertmanager.Silence, error) {
+	return m.MockGetSilence(ctx, id)
+}
+
+func (m *mockSilenceClient) ListSilences(ctx context.Context) ([]alertmanager.Silence, error) {
+	return m.MockListSilences(ctx)
+}
+
+func (m *mockSilenceClient) CreateSilence(ctx context.Context, s alertmanager.Silence) (string, error) {
+	return m.MockCreateSilence(ctx, s)
+}
+
+func (m *mockSilenceClient) DeleteSilence(ctx context.Context, id string) error {
+	return m.MockDeleteSilence(ctx, id)
+}
+
+var (
+	now     = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	endsAt  = now.Add(2 * time.Hour)
+	errBoom = errors.New("boom")
+)
+
+type silenceModifier func(*v1alpha1.Silence)
+
+func withExternalName(id string) silenceModifier {
+	return func(cr *v1alpha1.Silence) { meta.SetExternalName(cr, id) }
+}
+
+func withComment(c string) silenceModifier {
+	return func(cr *v1alpha1.Silence) { cr.Spec.ForProvider.Comment = c }
+}
+
+func withStartsAt(t time.Time) silenceModifier {
+	return func(cr *v1alpha1.Silence) { cr.Spec.ForProvider.StartsAt = &metav1.Time{Time: t} }
+}
+
+func withDeletionTimestamp() silenceModifier {
+	return func(cr *v1alpha1.Silence) { cr.SetDeletionTimestamp(&metav1.Time{Time: now}) }
+}
+
+func silence(mods ...silenceModifier) *v1alpha1.Silence {
+	cr := &v1alpha1.Silence{}
+	cr.SetName("maintenance")
+	cr.Spec.ForProvider = v1alpha1.SilenceParameters{
+		Matchers:  []v1alpha1.Matcher{{Name: "cluster", Value: "prod"}, {Name: "alertname", Value: "Watchdog"}},
+		EndsAt:    metav1.Time{Time: endsAt},
+		CreatedBy: "ops",
+		Comment:   "maintenance",
+	}
+	for _, m := range mods {
+		m(cr)
+	}
+	return cr
+}
+
+// observedSilence returns the silence the Alertmanager would return for the
+// default spec.
+func observedSilence(state string, startsAt time.Time) *alertmanager.Silence {
+	return &alertmanager.Silence{
+		ID: "abc",
+		Matchers: []alertmanager.Matcher{
+			{Name: "alertname", Value: "Watchdog", IsEqual: true},
+			{Name: "cluster", Value: "prod", IsEqual: true},
+		},
+		StartsAt:  startsAt,
+		EndsAt:    endsAt.Add(123 * time.Millisecond),
+		CreatedBy: "ops",
+		Comment:   "maintenance",
+		Status:    &alertmanager.SilenceStatus{State: state},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o         managed.ExternalObservation
+		state     string
+		condition *xpv1.Condition
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       *v1alpha1.Silence
+		observed *alertmanager.Silence
+		err      error
+		want     want
+	}{
+		"NoExternalName": {
+			reason: "A Silence without an ID should not exist.",
+			cr:     silence(),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NotFound": {
+			reason: "A silence the Alertmanager does not know should not exist.",
+			cr:     silence(withExternalName("abc")),
+			err:    errors.New("requested resource not found"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"GetError": {
+			reason: "An error getting the silence should be returned.",
+			cr:     silence(withExternalName("abc")),
+			err:    errBoom,
+			want:   want{err: errors.Wrap(errBoom, errGetSilence)},
+		},
+		"Active": {
+			reason:   "An active silence matching the spec should be available and up to date.",
+			cr:       silence(withExternalName("abc")),
+			observed: observedSilence(alertmanager.SilenceStateActive, now.Add(-time.Hour)),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				state:     alertmanager.SilenceStateActive,
+				condition: func() *xpv1.Condition { c := xpv1.Available(); return &c }(),
+			},
+		},
+		"Pending": {
+			reason:   "A silence that has not started yet should not be available.",
+			cr:       silence(withExternalName("abc"), withStartsAt(now.Add(time.Hour))),
+			observed: observedSilence(alertmanager.SilenceStatePending, now.Add(time.Hour)),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				state: alertmanager.SilenceStatePending,
+				condition: func() *xpv1.Condition {
+					c := xpv1.Unavailable().WithMessage("silence starts at 2023-06-01T13:00:00Z")
+					return &c
+				}(),
+			},
+		},
+		"StartChanged": {
+			reason:   "A silence that has not started yet should be updated if its start changed.",
+			cr:       silence(withExternalName("abc"), withStartsAt(now.Add(2*time.Hour))),
+			observed: observedSilence(alertmanager.SilenceStatePending, now.Add(time.Hour)),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				state: alertmanager.SilenceStatePending,
+				condition: func() *xpv1.Condition {
+					c := xpv1.Unavailable().WithMessage("silence starts at 2023-06-01T13:00:00Z")
+					return &c
+				}(),
+			},
+		},
+		"CommentChanged": {
+			reason:   "A silence should be updated if its comment changed.",
+			cr:       silence(withExternalName("abc"), withComment("extended maintenance")),
+			observed: observedSilence(alertmanager.SilenceStateActive, now.Add(-time.Hour)),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				state:     alertmanager.SilenceStateActive,
+				condition: func() *xpv1.Condition { c := xpv1.Available(); return &c }(),
+			},
+		},
+		"ExpiredEarly": {
+			reason:   "A silence expired before the end of the spec should be created again.",
+			cr:       silence(withExternalName("abc")),
+			observed: observedSilence(alertmanager.SilenceStateExpired, now.Add(-time.Hour)),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: false},
+				state: alertmanager.SilenceStateExpired,
+			},
+		},
+		"ExpiredDeleted": {
+			reason:   "An expired silence should not be expired again on delete.",
+			cr:       silence(withExternalName("abc"), withDeletionTimestamp()),
+			observed: observedSilence(alertmanager.SilenceStateExpired, now.Add(-time.Hour)),
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: false},
+				state: alertmanager.SilenceStateExpired,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				service: &mockSilenceClient{
+					MockGetSilence: func(_ context.Context, id string) (*alertmanager.Silence, error) {
+						if id != "abc" {
+							return nil, errors.New("unexpected ID")
+						}
+						return tc.observed, tc.err
+					},
+				},
+				now: func() time.Time { return now },
+			}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.state, tc.cr.Status.AtProvider.State); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want state, +got state:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.condition != nil {
+				if diff := cmp.Diff(*tc.want.condition, tc.cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		externalName string
+		created      *alertmanager.Silence
+		err          error
+	}
+
+	generated := &alertmanager.Silence{
+		Matchers: []alertmanager.Matcher{
+			{Name: "cluster", Value: "prod", IsEqual: true},
+			{Name: "alertname", Value: "Watchdog", IsEqual: true},
+		},
+		StartsAt:  now,
+		EndsAt:    endsAt,
+		CreatedBy: "ops",
+		Comment:   "maintenance",
+	}
+
+	replaced := func(id, state string) alertmanager.Silence {
+		s := observedSilence(state, now)
+		s.ID = id
+		return *s
+	}
+
+	other := replaced("other", alertmanager.SilenceStateActive)
+	other.Comment = "other"
+
+	cases := map[string]struct {
+		reason   string
+		silences []alertmanager.Silence
+		err      error
+		want     want
+	}{
+		"Created": {
+			reason:   "A silence should be created if no matching one exists.",
+			silences: []alertmanager.Silence{other},
+			want:     want{externalName: "abc", created: generated},
+		},
+		"Adopted": {
+			reason:   "A matching silence, e.g. the replacement of an updated one, should be adopted rather than created again.",
+			silences: []alertmanager.Silence{replaced("def", alertmanager.SilenceStateActive)},
+			want:     want{externalName: "def"},
+		},
+		"ExpiredNotAdopted": {
+			reason:   "An expired silence should not be adopted.",
+			silences: []alertmanager.Silence{replaced("def", alertmanager.SilenceStateExpired)},
+			want:     want{externalName: "abc", created: generated},
+		},
+		"ListError": {
+			reason: "No silence should be created if the existing ones cannot be listed.",
+			err:    errBoom,
+			want:   want{err: errors.Wrap(errBoom, errListSilences)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created *alertmanager.Silence
+			e := external{
+				service: &mockSilenceClient{
+					MockListSilences: func(_ context.Context) ([]alertmanager.Silence, error) {
+						return tc.silences, tc.err
+					},
+					MockCreateSilence: func(_ context.Context, s alertmanager.Silence) (string, error) {
+						created = &s
+						return "abc", nil
+					},
+				},
+				now: func() time.Time { return now },
+			}
+
+			cr := silence()
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.created, created); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want silence, +got silence:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		id     string
+		err    error
+		want   want
+	}{
+		"SameID": {
+			reason: "A silence replaced in place should keep its ID.",
+			id:     "abc",
+			want:   want{externalName: "abc"},
+		},
+		"NewID": {
+			reason: "The ID of a silence the Alertmanager replaced by a new one should be left for Create to adopt the new one.",
+			id:     "def",
+			want:   want{externalName: "abc"},
+		},
+		"Error": {
+			reason: "An error replacing the silence should be returned.",
+			err:    errBoom,
+			want:   want{externalName: "abc", err: errors.Wrap(errBoom, errUpdateSilence)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				service: &mockSilenceClient{
+					MockCreateSilence: func(_ context.Context, s alertmanager.Silence) (string, error) {
+						if s.ID != "abc" {
+							return "", errors.New("unexpected ID")
+						}
+						return tc.id, tc.err
+					},
+				},
+				now: func() time.Time { return now },
+			}
+			cr := silence(withExternalName("abc"))
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Expired": {
+			reason: "The silence should be expired.",
+		},
+		"NotFound": {
+			reason: "A silence the Alertmanager does not know should be ignored.",
+			err:    errors.New("requested resource not found"),
+		},
+		"Error": {
+			reason: "An error expiring the silence should be returned.",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errDeleteSilence),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				service: &mockSilenceClient{
+					MockDeleteSilence: func(_ context.Context, id string) error {
+						if id != "abc" {
+							return errors.New("unexpected ID")
+						}
+						return tc.err
+					},
+				},
+			}
+			err := e.Delete(context.Background(), silence(withExternalName("abc")))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantdeletion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/swisscom/provider-cortex/apis/tenants/v1alpha1"
+	apisv1alpha1 "github.com/swisscom/provider-cortex/apis/v1alpha1"
+	xpClient "github.com/swisscom/provider-cortex/internal/clients"
+	"github.com/swisscom/provider-cortex/internal/clients/tenants"
+	"github.com/swisscom/provider-cortex/internal/features"
+)
+
+const (
+	errNotTenantDeletion = "managed resource is not a TenantDeletion custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errNewClient         = "cannot create new Service"
+	errNoTenant          = "ProviderConfig does not set a tenant"
+	errGetStatus         = "cannot get tenant deletion status"
+	errDeleteTenant      = "cannot request tenant deletion"
+
+	errFmtTenantChanged = "the deletion was requested for tenant %q, but the ProviderConfig now refers to tenant %q; create a new TenantDeletion to delete it"
+)
+
+// Setup adds a controller that reconciles TenantDeletion managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.TenantDeletionGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TenantDeletionGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: newTenantClient}),
+		// The external name is the tenant the deletion was requested for.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.TenantDeletion{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(config xpClient.Config) tenants.TenantClient
+}
+
+func newTenantClient(config xpClient.Config) tenants.TenantClient {
+	return xpClient.NewClient(config)
+}
+
+// Connect produces an ExternalClient for the tenant of the ProviderConfig of
+// the TenantDeletion.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.TenantDeletion)
+	if !ok {
+		return nil, errors.New(errNotTenantDeletion)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	config, err := xpClient.GetConfig(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	if config.TenantID() == "" {
+		return nil, errors.New(errNoTenant)
+	}
+
+	return &external{service: c.newServiceFn(*config), tenant: config.TenantID()}, nil
+}
+
+// An ExternalClient requests the deletion of a tenant and observes its
+// progress.
+type external struct {
+	service tenants.TenantClient
+
+	// The tenant of the ProviderConfig
+	tenant string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TenantDeletion)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTenantDeletion)
+	}
+
+	// A deleted tenant cannot be restored, so there is nothing to delete.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// The deletion was not requested yet.
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// The ProviderConfig was changed or reused for another tenant since the
+	// deletion was requested. Its data must not be deleted without asking.
+	if meta.GetExternalName(cr) != c.tenant {
+		err := errors.Errorf(errFmtTenantChanged, meta.GetExternalName(cr), c.tenant)
+		cr.Status.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+		return managed.ExternalObservation{}, err
+	}
+
+	s, err := c.service.GetTenantDeletionStatus(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetStatus)
+	}
+
+	cr.Status.AtProvider = v1alpha1.TenantDeletionObservation{
+		TenantID:      c.tenant,
+		BlocksDeleted: s.BlocksDeleted,
+	}
+
+	if s.BlocksDeleted {
+		cr.Status.SetConditions(xpv1.Available())
+	} else {
+		cr.Status.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("blocks of tenant %q are being deleted", c.tenant)))
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TenantDeletion)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTenantDeletion)
+	}
+
+	if err := c.service.DeleteTenant(ctx); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDeleteTenant)
+	}
+
+	meta.SetExternalName(cr, c.tenant)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	// A TenantDeletion has nothing to update.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(_ context.Context, _ resource.Managed) error {
+	// A deleted tenant cannot be restored.
+	return nil
+}
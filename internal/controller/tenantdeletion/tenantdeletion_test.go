@@ -0,0 +1,231 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantdeletion
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/swisscom/provider-cortex/apis/tenants/v1alpha1"
+	"github.com/swisscom/provider-cortex/internal/clients/tenants"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type mockTenantClient struct {
+	MockDeleteTenant            func(ctx context.Context) error
+	MockGetTenantDeletionStatus func(ctx context.Context) (*tenants.DeletionStatus, error)
+}
+
+func (m *mockTenantClient) DeleteTenant(ctx context.Context) error {
+	return m.MockDeleteTenant(ctx)
+}
+
+func (m *mockTenantClient) GetTenantDeletionStatus(ctx context.Context) (*tenants.DeletionStatus, error) {
+	return m.MockGetTenantDeletionStatus(ctx)
+}
+
+var deletedAt = metav1.Now()
+
+type tenantDeletionModifier func(*v1alpha1.TenantDeletion)
+
+func withExternalName(tenant string) tenantDeletionModifier {
+	return func(cr *v1alpha1.TenantDeletion) { meta.SetExternalName(cr, tenant) }
+}
+
+func withDeletionTimestamp() tenantDeletionModifier {
+	return func(cr *v1alpha1.TenantDeletion) { cr.SetDeletionTimestamp(&deletedAt) }
+}
+
+func withConditions(c ...xpv1.Condition) tenantDeletionModifier {
+	return func(cr *v1alpha1.TenantDeletion) { cr.Status.SetConditions(c...) }
+}
+
+func withObservation(o v1alpha1.TenantDeletionObservation) tenantDeletionModifier {
+	return func(cr *v1alpha1.TenantDeletion) { cr.Status.AtProvider = o }
+}
+
+func tenantDeletion(mods ...tenantDeletionModifier) *v1alpha1.TenantDeletion {
+	cr := &v1alpha1.TenantDeletion{}
+	cr.SetName("offboard-team-a")
+	for _, m := range mods {
+		m(cr)
+	}
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		cr  *v1alpha1.TenantDeletion
+		err error
+	}
+
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.TenantDeletion
+		status *tenants.DeletionStatus
+		err    error
+		want   want
+	}{
+		"NotRequested": {
+			reason: "A deletion that was not requested yet should not exist.",
+			cr:     tenantDeletion(),
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: false},
+				cr: tenantDeletion(),
+			},
+		},
+		"OtherTenant": {
+			reason: "A deletion requested for another tenant than the one of the ProviderConfig should be an error, so that the tenant of the ProviderConfig is not deleted too.",
+			cr:     tenantDeletion(withExternalName("team-b")),
+			want: want{
+				err: errors.Errorf(errFmtTenantChanged, "team-b", "team-a"),
+				cr: tenantDeletion(
+					withExternalName("team-b"),
+					withConditions(xpv1.Unavailable().WithMessage(`the deletion was requested for tenant "team-b", but the ProviderConfig now refers to tenant "team-a"; create a new TenantDeletion to delete it`)),
+				),
+			},
+		},
+		"InProgress": {
+			reason: "A deletion whose blocks are not deleted yet should not be ready.",
+			cr:     tenantDeletion(withExternalName("team-a")),
+			status: &tenants.DeletionStatus{BlocksDeleted: false},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: tenantDeletion(
+					withExternalName("team-a"),
+					withObservation(v1alpha1.TenantDeletionObservation{TenantID: "team-a"}),
+					withConditions(xpv1.Unavailable().WithMessage(`blocks of tenant "team-a" are being deleted`)),
+				),
+			},
+		},
+		"Complete": {
+			reason: "A deletion whose blocks are deleted should be ready.",
+			cr:     tenantDeletion(withExternalName("team-a")),
+			status: &tenants.DeletionStatus{BlocksDeleted: true},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: tenantDeletion(
+					withExternalName("team-a"),
+					withObservation(v1alpha1.TenantDeletionObservation{TenantID: "team-a", BlocksDeleted: true}),
+					withConditions(xpv1.Available()),
+				),
+			},
+		},
+		"StatusError": {
+			reason: "An error getting the deletion status should be returned.",
+			cr:     tenantDeletion(withExternalName("team-a")),
+			err:    errBoom,
+			want: want{
+				err: errors.Wrap(errBoom, errGetStatus),
+				cr:  tenantDeletion(withExternalName("team-a")),
+			},
+		},
+		"Deleted": {
+			reason: "A deleted TenantDeletion should not exist so its finalizer is removed.",
+			cr:     tenantDeletion(withExternalName("team-a"), withDeletionTimestamp()),
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: false},
+				cr: tenantDeletion(withExternalName("team-a"), withDeletionTimestamp()),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				service: &mockTenantClient{
+					MockGetTenantDeletionStatus: func(_ context.Context) (*tenants.DeletionStatus, error) {
+						return tc.status, tc.err
+					},
+				},
+				tenant: "team-a",
+			}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		externalName string
+		err          error
+	}
+
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"Requested": {
+			reason: "The tenant the deletion was requested for should be recorded.",
+			want:   want{externalName: "team-a"},
+		},
+		"Error": {
+			reason: "An error requesting the deletion should be returned.",
+			err:    errBoom,
+			want:   want{err: errors.Wrap(errBoom, errDeleteTenant)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				service: &mockTenantClient{
+					MockDeleteTenant: func(_ context.Context) error { return tc.err },
+				},
+				tenant: "team-a",
+			}
+			cr := tenantDeletion()
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}